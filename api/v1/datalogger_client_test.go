@@ -0,0 +1,47 @@
+package v1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg/generated/clientset/versioned/fake"
+	"stackit.cloud/datalogger/pkg/generated/informers/externalversions"
+)
+
+func TestDataLoggerClientset(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientset := fake.NewSimpleClientset()
+
+	factory := externalversions.NewSharedInformerFactory(clientset, time.Minute)
+	lister := factory.App().V1().DataLoggers().Lister()
+
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample-42", Namespace: "my-namespace1"},
+		Spec:       appv1.DataLoggerSpec{CustomName: "datalogger-42", Replicas: 1, Port: 8080, TargetPort: 80},
+	}
+
+	created, err := clientset.AppV1().DataLoggers("my-namespace1").Create(ctx, dataLogger, metav1.CreateOptions{})
+	require.Nil(t, err)
+
+	created.Status.Endpoint = "datalogger-42.my-namespace1.svc:8080"
+	_, err = clientset.AppV1().DataLoggers("my-namespace1").UpdateStatus(ctx, created, metav1.UpdateOptions{})
+	require.Nil(t, err)
+
+	require.Eventually(t, func() bool {
+		cached, err := lister.DataLoggers("my-namespace1").Get("datalogger-sample-42")
+		return err == nil && cached.Status.Endpoint == "datalogger-42.my-namespace1.svc:8080"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = lister.DataLoggers("my-namespace2").Get("datalogger-sample-42")
+	require.True(t, apierrors.IsNotFound(err))
+}
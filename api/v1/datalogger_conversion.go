@@ -0,0 +1,159 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	appv2 "stackit.cloud/datalogger/api/v2"
+)
+
+// StorageAnnotation keeps the v2 storage settings of a DataLogger served as v1,
+// as v1 has no field for them and they would be lost on a round trip
+const StorageAnnotation = "app.stackit.cloud/v2-storage"
+
+var _ conversion.Convertible = &DataLogger{}
+
+// ConvertTo converts this DataLogger to the hub version (v2)
+func (d *DataLogger) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*appv2.DataLogger)
+
+	dst.ObjectMeta = *d.ObjectMeta.DeepCopy()
+	dst.Spec = toHubSpec(&d.Spec)
+
+	if raw, ok := dst.Annotations[StorageAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &dst.Spec.Storage); err != nil {
+			return err
+		}
+
+		delete(dst.Annotations, StorageAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	status := d.Status.DeepCopy()
+	dst.Status = appv2.DataLoggerStatus{
+		Conditions:         status.Conditions,
+		ObservedGeneration: status.ObservedGeneration,
+		Replicas:           status.Replicas,
+		ReadyReplicas:      status.ReadyReplicas,
+		Endpoint:           status.Endpoint,
+		FirstReadyTime:     status.FirstReadyTime,
+		LastDriftTime:      status.LastDriftTime,
+	}
+
+	for _, component := range status.Components {
+		dst.Status.Components = append(dst.Status.Components, appv2.ComponentStatus{
+			Name:    component.Name,
+			Phase:   appv2.ComponentPhase(component.Phase),
+			Message: component.Message,
+		})
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the hub version (v2) to this DataLogger
+func (d *DataLogger) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*appv2.DataLogger)
+
+	d.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	d.Spec = fromHubSpec(&src.Spec)
+
+	if src.Spec.Storage != (appv2.StorageSpec{}) {
+		raw, err := json.Marshal(src.Spec.Storage)
+		if err != nil {
+			return err
+		}
+
+		if d.Annotations == nil {
+			d.Annotations = map[string]string{}
+		}
+		d.Annotations[StorageAnnotation] = string(raw)
+	}
+
+	status := src.Status.DeepCopy()
+	d.Status = DataLoggerStatus{
+		Conditions:         status.Conditions,
+		ObservedGeneration: status.ObservedGeneration,
+		Replicas:           status.Replicas,
+		ReadyReplicas:      status.ReadyReplicas,
+		Endpoint:           status.Endpoint,
+		FirstReadyTime:     status.FirstReadyTime,
+		LastDriftTime:      status.LastDriftTime,
+	}
+
+	for _, component := range status.Components {
+		d.Status.Components = append(d.Status.Components, ComponentStatus{
+			Name:    component.Name,
+			Phase:   ComponentPhase(component.Phase),
+			Message: component.Message,
+		})
+	}
+
+	return nil
+}
+
+// toHubSpec converts the v1 spec to the spec of the hub version (v2)
+func toHubSpec(src *DataLoggerSpec) appv2.DataLoggerSpec {
+	spec := src.DeepCopy()
+
+	return appv2.DataLoggerSpec{
+		CustomName: spec.CustomName,
+		Workload: appv2.WorkloadSpec{
+			Replicas:         spec.Replicas,
+			Image:            spec.Image,
+			ImagePullPolicy:  spec.ImagePullPolicy,
+			ImagePullSecrets: spec.ImagePullSecrets,
+			Command:          spec.Command,
+			Args:             spec.Args,
+			Env:              spec.Env,
+			EnvFrom:          spec.EnvFrom,
+		},
+		Networking: appv2.NetworkingSpec{
+			Port:        spec.Port,
+			TargetPort:  spec.TargetPort,
+			NodePort:    spec.NodePort,
+			ServiceType: spec.ServiceType,
+		},
+		DeletionPolicy: appv2.DeletionPolicy(spec.DeletionPolicy),
+	}
+}
+
+// fromHubSpec converts the spec of the hub version (v2) to the v1 spec
+func fromHubSpec(src *appv2.DataLoggerSpec) DataLoggerSpec {
+	spec := src.DeepCopy()
+
+	return DataLoggerSpec{
+		CustomName:       spec.CustomName,
+		Replicas:         spec.Workload.Replicas,
+		Port:             spec.Networking.Port,
+		NodePort:         spec.Networking.NodePort,
+		TargetPort:       spec.Networking.TargetPort,
+		ServiceType:      spec.Networking.ServiceType,
+		Image:            spec.Workload.Image,
+		ImagePullPolicy:  spec.Workload.ImagePullPolicy,
+		ImagePullSecrets: spec.Workload.ImagePullSecrets,
+		Command:          spec.Workload.Command,
+		Args:             spec.Workload.Args,
+		Env:              spec.Workload.Env,
+		EnvFrom:          spec.Workload.EnvFrom,
+		DeletionPolicy:   DeletionPolicy(spec.DeletionPolicy),
+	}
+}
@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appv2 "stackit.cloud/datalogger/api/v2"
+)
+
+func TestDataLoggerConvertTo(t *testing.T) {
+	tests := []struct {
+		name       string
+		dataLogger *DataLogger
+	}{
+		{
+			name: "example",
+			dataLogger: &DataLogger{
+				ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample", Namespace: "my-namespace1"},
+				Spec:       DataLoggerSpec{CustomName: "datalogger-42", Port: 8080, TargetPort: 80, NodePort: 32101},
+			},
+		},
+		{
+			name: "full",
+			dataLogger: &DataLogger{
+				ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample", Labels: map[string]string{"app": "logger"}},
+				Spec: DataLoggerSpec{
+					CustomName:       "datalogger-42",
+					Replicas:         3,
+					Port:             8080,
+					TargetPort:       80,
+					ServiceType:      corev1.ServiceTypeClusterIP,
+					Image:            "registry.example/logger:1.0",
+					ImagePullPolicy:  corev1.PullAlways,
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+					Command:          []string{"logger"},
+					Args:             []string{"--verbose"},
+					Env:              []corev1.EnvVar{{Name: "LEVEL", Value: "debug"}},
+					DeletionPolicy:   DeletionPolicyRetain,
+				},
+				Status: DataLoggerStatus{
+					ObservedGeneration: 2,
+					ReadyReplicas:      3,
+					Endpoint:           "datalogger-42.my-namespace1.svc:8080",
+					FirstReadyTime:     &metav1.Time{Time: time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)},
+					Conditions:         []metav1.Condition{{Type: ConditionReady, Status: metav1.ConditionTrue}},
+					Components: []ComponentStatus{
+						{Name: "deployment", Phase: ComponentFailed, Message: "quota exceeded"},
+						{Name: "service", Phase: ComponentBlocked, Message: "deployment failed"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			hub := &appv2.DataLogger{}
+			require.NoError(t, test.dataLogger.ConvertTo(hub))
+
+			require.EqualValues(t, test.dataLogger.Spec.CustomName, hub.Spec.CustomName)
+			require.EqualValues(t, test.dataLogger.Spec.Replicas, hub.Spec.Workload.Replicas)
+			require.EqualValues(t, test.dataLogger.Spec.DeletionPolicy, hub.Spec.DeletionPolicy)
+			require.EqualValues(t, test.dataLogger.Spec.NodePort, hub.Spec.Networking.NodePort)
+			require.EqualValues(t, test.dataLogger.Status.Endpoint, hub.Status.Endpoint)
+			require.EqualValues(t, len(test.dataLogger.Status.Components), len(hub.Status.Components))
+
+			converted := &DataLogger{}
+			require.NoError(t, converted.ConvertFrom(hub))
+
+			require.EqualValues(t, test.dataLogger.ObjectMeta, converted.ObjectMeta)
+			require.EqualValues(t, test.dataLogger.Spec, converted.Spec)
+			require.EqualValues(t, test.dataLogger.Status, converted.Status)
+		})
+	}
+}
+
+func TestDataLoggerConvertFrom(t *testing.T) {
+	size := resource.MustParse("1Gi")
+	storageClass := "standard"
+
+	tests := []struct {
+		name       string
+		dataLogger *appv2.DataLogger
+		annotated  bool
+	}{
+		{
+			name: "without-storage",
+			dataLogger: &appv2.DataLogger{
+				ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample"},
+				Spec: appv2.DataLoggerSpec{
+					CustomName: "datalogger-42",
+					Workload:   appv2.WorkloadSpec{Replicas: 2},
+					Networking: appv2.NetworkingSpec{Port: 8080, TargetPort: 80, NodePort: 32101},
+				},
+			},
+			annotated: false,
+		},
+		{
+			name: "with-storage",
+			dataLogger: &appv2.DataLogger{
+				ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample", Annotations: map[string]string{"team": "logging"}},
+				Spec: appv2.DataLoggerSpec{
+					CustomName:     "datalogger-42",
+					Networking:     appv2.NetworkingSpec{Port: 8080, TargetPort: 80},
+					Storage:        appv2.StorageSpec{Size: &size, StorageClassName: &storageClass, MountPath: "/data"},
+					DeletionPolicy: appv2.DeletionPolicyRetain,
+				},
+			},
+			annotated: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			spoke := &DataLogger{}
+			require.NoError(t, spoke.ConvertFrom(test.dataLogger))
+
+			require.EqualValues(t, test.dataLogger.Spec.Networking.TargetPort, spoke.Spec.TargetPort)
+
+			_, annotated := spoke.Annotations[StorageAnnotation]
+			require.EqualValues(t, test.annotated, annotated)
+
+			hub := &appv2.DataLogger{}
+			require.NoError(t, spoke.ConvertTo(hub))
+
+			require.EqualValues(t, test.dataLogger.ObjectMeta, hub.ObjectMeta)
+			require.EqualValues(t, test.dataLogger.Spec.CustomName, hub.Spec.CustomName)
+			require.EqualValues(t, test.dataLogger.Spec.Workload, hub.Spec.Workload)
+			require.EqualValues(t, test.dataLogger.Spec.Networking, hub.Spec.Networking)
+			require.EqualValues(t, test.dataLogger.Spec.DeletionPolicy, hub.Spec.DeletionPolicy)
+			require.EqualValues(t, test.dataLogger.Spec.Storage.MountPath, hub.Spec.Storage.MountPath)
+			require.EqualValues(t, test.dataLogger.Spec.Storage.StorageClassName, hub.Spec.Storage.StorageClassName)
+			if test.dataLogger.Spec.Storage.Size != nil {
+				require.Zero(t, test.dataLogger.Spec.Storage.Size.Cmp(*hub.Spec.Storage.Size))
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	appv2 "stackit.cloud/datalogger/api/v2"
+)
+
+// DataLoggerDefaulter fills the fields of a v1 DataLogger spec that were left empty, as the defaulting
+// webhook of the hub does. The admission webhooks are only registered for v2, see appv2.DataLogger.
+// +kubebuilder:object:generate=false
+type DataLoggerDefaulter appv2.DataLoggerDefaulter
+
+// SetDefaults fills the empty fields of the spec
+func (d *DataLoggerDefaulter) SetDefaults(spec *DataLoggerSpec) {
+	hub := toHubSpec(spec)
+	(*appv2.DataLoggerDefaulter)(d).SetDefaults(&hub)
+	*spec = fromHubSpec(&hub)
+}
+
+// ValidateSpec returns the field errors of a DataLogger spec. The paths are the ones of the hub,
+// as reported by the validating webhook.
+func ValidateSpec(spec *DataLoggerSpec, specPath *field.Path) field.ErrorList {
+	hub := toHubSpec(spec)
+
+	return appv2.ValidateSpec(&hub, specPath)
+}
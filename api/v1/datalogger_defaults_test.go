@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestDataLoggerDefaulterSetDefaults(t *testing.T) {
+	tests := []struct {
+		name      string
+		defaulter *DataLoggerDefaulter
+		spec      DataLoggerSpec
+		want      DataLoggerSpec
+	}{
+		{
+			name:      "documented-defaults",
+			defaulter: &DataLoggerDefaulter{},
+			spec:      DataLoggerSpec{CustomName: "datalogger-42"},
+			want: DataLoggerSpec{
+				CustomName: "datalogger-42", Replicas: 1, Port: 8080, TargetPort: 8080,
+				ServiceType: corev1.ServiceTypeClusterIP, DeletionPolicy: DeletionPolicyDelete,
+			},
+		},
+		{
+			name:      "node-port",
+			defaulter: &DataLoggerDefaulter{Replicas: 2, ServiceType: corev1.ServiceTypeLoadBalancer},
+			spec:      DataLoggerSpec{CustomName: "datalogger-42", TargetPort: 80, NodePort: 32101},
+			want: DataLoggerSpec{
+				CustomName: "datalogger-42", Replicas: 2, Port: 8080, TargetPort: 80, NodePort: 32101,
+				ServiceType: corev1.ServiceTypeNodePort, DeletionPolicy: DeletionPolicyDelete,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			spec := test.spec
+			test.defaulter.SetDefaults(&spec)
+			require.EqualValues(t, test.want, spec)
+		})
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	spec := &DataLoggerSpec{CustomName: "DataLogger_42", Port: 8080, NodePort: 8080}
+
+	fields := []string{}
+	for _, err := range ValidateSpec(spec, field.NewPath("spec")) {
+		fields = append(fields, err.Field)
+	}
+
+	require.EqualValues(t, []string{"spec.customName", "spec.networking.targetPort", "spec.networking.nodePort"}, fields)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -34,12 +35,122 @@ type DataLoggerSpec struct {
 	Port       int32  `json:"port,omitempty"`
 	NodePort   int32  `json:"node-port,omitempty"`
 	TargetPort int32  `json:"target-port,omitempty"`
+
+	// ServiceType of the logger service, defaults to NodePort when a node-port is set
+	// and to the operator setting otherwise
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	ServiceType corev1.ServiceType `json:"service-type,omitempty"`
+
+	// Image is the container image of the logger, defaults to the operator setting
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ImagePullPolicy of the logger container, defaults to the operator setting
+	// +optional
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"image-pull-policy,omitempty"`
+	// ImagePullSecrets used to pull the image from a private registry
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"image-pull-secrets,omitempty"`
+	// Command overrides the entrypoint of the image
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args overrides the arguments of the entrypoint
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env is a list of additional environment variables of the logger container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// EnvFrom is a list of sources to populate environment variables of the logger container
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"env-from,omitempty"`
+
+	// DeletionPolicy decides what happens to the children when the DataLogger is deleted, defaults to Delete
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletion-policy,omitempty"`
 }
 
+// DeletionPolicy decides what happens to the children of a DataLogger when it is deleted
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the children and waits until they are gone
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the Deployment and Service and removes their owner references
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyOrphan removes only the DataLogger and removes the owner references of the children,
+	// so that the garbage collector does not delete them
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// Condition types reported in DataLoggerStatus.Conditions
+const (
+	// ConditionReady is true when all children of the DataLogger are available
+	ConditionReady = "Ready"
+	// ConditionDeploymentAvailable mirrors the Available condition of the managed Deployment
+	ConditionDeploymentAvailable = "DeploymentAvailable"
+	// ConditionServiceReady is true when the managed Service exists
+	ConditionServiceReady = "ServiceReady"
+	// ConditionFinalizing is true while the finalizer is cleaning up the children
+	ConditionFinalizing = "Finalizing"
+	// ConditionFinalizeStuck is true when a child of the deleted DataLogger is not gone within the finalize timeout
+	ConditionFinalizeStuck = "FinalizeStuck"
+	// ConditionDrifted is true when the last reconcile reverted changes made by hand to the children
+	ConditionDrifted = "Drifted"
+)
+
 // DataLoggerStatus defines the observed state of DataLogger
 type DataLoggerStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Conditions represent the latest available observations of the DataLogger's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Replicas is the number of desired pods of the managed Deployment
+	Replicas int32 `json:"replicas,omitempty"`
+	// ReadyReplicas is the number of ready pods of the managed Deployment
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Endpoint is the in-cluster address of the managed Service
+	Endpoint string `json:"endpoint,omitempty"`
+	// FirstReadyTime is the time the Ready condition turned true for the first time
+	// +optional
+	FirstReadyTime *metav1.Time `json:"firstReadyTime,omitempty"`
+	// LastDriftTime is the last time a managed resource was found changed by hand
+	// +optional
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+	// Components are the outcomes of the last run of the sub-reconcilers of the DataLogger
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+// ComponentPhase is the outcome of the last run of a sub-reconciler
+type ComponentPhase string
+
+const (
+	// ComponentSucceeded means the sub-reconciler ran without an error
+	ComponentSucceeded ComponentPhase = "Succeeded"
+	// ComponentFailed means the sub-reconciler returned an error
+	ComponentFailed ComponentPhase = "Failed"
+	// ComponentBlocked means the sub-reconciler did not run, as a sub-reconciler it depends on failed
+	ComponentBlocked ComponentPhase = "Blocked"
+)
+
+// ComponentStatus is the outcome of the last run of a sub-reconciler of the DataLogger
+type ComponentStatus struct {
+	// Name the sub-reconciler is registered under, e.g. deployment
+	Name string `json:"name"`
+	// Phase is the outcome of the last run
+	// +kubebuilder:validation:Enum=Succeeded;Failed;Blocked
+	Phase ComponentPhase `json:"phase"`
+	// Message is the error of a failed sub-reconciler or the failed dependency of a blocked one
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type MetaDataLogger struct {
@@ -47,8 +158,13 @@ type MetaDataLogger struct {
 	Finalizers      []string `json:"finalizers,omitempty"`
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.readyReplicas`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.status.endpoint`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // DataLogger is the Schema for the dataloggers API
 type DataLogger struct {
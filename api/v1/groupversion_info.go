@@ -14,9 +14,6 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package v1 contains API Schema definitions for the app v1 API group
-// +kubebuilder:object:generate=true
-// +groupName=app.stackit.cloud
 package v1
 
 import (
@@ -28,6 +25,9 @@ var (
 	// GroupVersion is group version used to register these objects
 	GroupVersion = schema.GroupVersion{Group: "app.stackit.cloud", Version: "v1"}
 
+	// SchemeGroupVersion is the name the generated clientset, informers and listers refer to GroupVersion by
+	SchemeGroupVersion = GroupVersion
+
 	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
 	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
 
@@ -35,6 +35,11 @@ var (
 	AddToScheme = SchemeBuilder.AddToScheme
 )
 
+// Resource takes an unqualified resource and returns a group qualified GroupResource
+func Resource(resource string) schema.GroupResource {
+	return GroupVersion.WithResource(resource).GroupResource()
+}
+
 // RBAC definitions
 
 //+kubebuilder:rbac:groups=app.stackit.cloud,resources=dataloggers,verbs=get;list;watch;create;update;patch;delete
@@ -46,3 +51,7 @@ var (
 // +kubebuilder:rbac:groups=core,resources=services/finalizers,verbs=update
 
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=create;get;list;watch;update;delete;patch
+
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;patch;delete
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
@@ -0,0 +1,198 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DataLoggerSpec defines the desired state of DataLogger
+type DataLoggerSpec struct {
+	// CustomName is the name of the Deployment and Service created for the DataLogger
+	CustomName string `json:"customName"`
+
+	// Workload configures the pods of the logger
+	// +optional
+	Workload WorkloadSpec `json:"workload,omitempty"`
+	// Networking configures the Service in front of the logger
+	// +optional
+	Networking NetworkingSpec `json:"networking,omitempty"`
+	// Storage configures the persistent volume of the logger
+	// +optional
+	Storage StorageSpec `json:"storage,omitempty"`
+	// DeletionPolicy decides what happens to the children when the DataLogger is deleted, defaults to Delete
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DeletionPolicy decides what happens to the children of a DataLogger when it is deleted
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the children and waits until they are gone
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the Deployment and Service and removes their owner references
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyOrphan removes only the DataLogger and removes the owner references of the children,
+	// so that the garbage collector does not delete them
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// WorkloadSpec defines the pods of the logger
+type WorkloadSpec struct {
+	// Replicas is the number of logger pods
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// Image is the container image of the logger, defaults to the operator setting
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ImagePullPolicy of the logger container, defaults to the operator setting
+	// +optional
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ImagePullSecrets used to pull the image from a private registry
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Command overrides the entrypoint of the image
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args overrides the arguments of the entrypoint
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env is a list of additional environment variables of the logger container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// EnvFrom is a list of sources to populate environment variables of the logger container
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+}
+
+// NetworkingSpec defines the Service of the logger
+type NetworkingSpec struct {
+	// Port the Service listens on
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// TargetPort is the port of the logger container
+	// +optional
+	TargetPort int32 `json:"targetPort,omitempty"`
+	// NodePort exposes the Service on every node, requires the NodePort or LoadBalancer type
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
+	// ServiceType of the logger service
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+}
+
+// StorageSpec defines the persistent volume of the logger
+type StorageSpec struct {
+	// Size of the volume, no volume is claimed when empty
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// StorageClassName of the volume claim, the cluster default is used when empty
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// MountPath of the volume inside the logger container
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// DataLoggerStatus defines the observed state of DataLogger
+type DataLoggerStatus struct {
+	// Conditions represent the latest available observations of the DataLogger's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Replicas is the number of desired pods of the managed Deployment
+	Replicas int32 `json:"replicas,omitempty"`
+	// ReadyReplicas is the number of ready pods of the managed Deployment
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Endpoint is the in-cluster address of the managed Service
+	Endpoint string `json:"endpoint,omitempty"`
+	// FirstReadyTime is the time the Ready condition turned true for the first time
+	// +optional
+	FirstReadyTime *metav1.Time `json:"firstReadyTime,omitempty"`
+	// LastDriftTime is the last time a managed resource was found changed by hand
+	// +optional
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+	// Components are the outcomes of the last run of the sub-reconcilers of the DataLogger
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+// ComponentPhase is the outcome of the last run of a sub-reconciler
+type ComponentPhase string
+
+const (
+	// ComponentSucceeded means the sub-reconciler ran without an error
+	ComponentSucceeded ComponentPhase = "Succeeded"
+	// ComponentFailed means the sub-reconciler returned an error
+	ComponentFailed ComponentPhase = "Failed"
+	// ComponentBlocked means the sub-reconciler did not run, as a sub-reconciler it depends on failed
+	ComponentBlocked ComponentPhase = "Blocked"
+)
+
+// ComponentStatus is the outcome of the last run of a sub-reconciler of the DataLogger
+type ComponentStatus struct {
+	// Name the sub-reconciler is registered under, e.g. deployment
+	Name string `json:"name"`
+	// Phase is the outcome of the last run
+	// +kubebuilder:validation:Enum=Succeeded;Failed;Blocked
+	Phase ComponentPhase `json:"phase"`
+	// Message is the error of a failed sub-reconciler or the failed dependency of a blocked one
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.readyReplicas`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.status.endpoint`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DataLogger is the Schema for the dataloggers API
+type DataLogger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataLoggerSpec   `json:"spec,omitempty"`
+	Status DataLoggerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DataLoggerList contains a list of DataLogger
+type DataLoggerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DataLogger `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DataLogger{}, &DataLoggerList{})
+}
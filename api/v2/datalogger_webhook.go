@@ -0,0 +1,239 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// NodePortMin and NodePortMax define the default node port range of a kubernetes cluster
+const (
+	NodePortMin = 30000
+	NodePortMax = 32767
+)
+
+// Documented defaults of the DataLogger spec, used when the operator configures nothing else
+const (
+	DefaultReplicas    int32              = 1
+	DefaultPort        int32              = 8080
+	DefaultServiceType corev1.ServiceType = corev1.ServiceTypeClusterIP
+)
+
+// SetupWebhookWithManager registers the admission webhooks of the DataLogger with the manager.
+// The conversion webhook is registered as well, as v2 is the conversion hub. Only the hub has admission
+// webhooks, the API server converts requests for the other versions to v2 before calling them.
+func (d *DataLogger) SetupWebhookWithManager(mgr ctrl.Manager, defaulter *DataLoggerDefaulter) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(d).
+		WithDefaulter(defaulter).
+		WithValidator(&DataLoggerValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-app-stackit-cloud-v2-datalogger,mutating=true,failurePolicy=fail,sideEffects=None,groups=app.stackit.cloud,resources=dataloggers,verbs=create;update,versions=v2,name=mdatalogger-v2.kb.io,admissionReviewVersions=v1,matchPolicy=Equivalent
+
+// DataLoggerDefaulter fills the fields of a DataLogger spec that were left empty.
+// The values are configured by the operator, zero values fall back to the documented defaults.
+// +kubebuilder:object:generate=false
+type DataLoggerDefaulter struct {
+	Replicas    int32
+	Port        int32
+	ServiceType corev1.ServiceType
+}
+
+var _ admission.CustomDefaulter = &DataLoggerDefaulter{}
+
+// Default implements admission.CustomDefaulter
+func (d *DataLoggerDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	dataLogger, err := toDataLogger(obj)
+	if err != nil {
+		return err
+	}
+
+	d.SetDefaults(&dataLogger.Spec)
+
+	return nil
+}
+
+// SetDefaults fills the empty fields of the spec
+func (d *DataLoggerDefaulter) SetDefaults(spec *DataLoggerSpec) {
+	if spec.Workload.Replicas == 0 {
+		spec.Workload.Replicas = d.Replicas
+		if spec.Workload.Replicas == 0 {
+			spec.Workload.Replicas = DefaultReplicas
+		}
+	}
+
+	networking := &spec.Networking
+	if networking.Port == 0 {
+		networking.Port = d.Port
+		if networking.Port == 0 {
+			networking.Port = DefaultPort
+		}
+	}
+
+	if networking.TargetPort == 0 {
+		networking.TargetPort = networking.Port
+	}
+
+	if networking.ServiceType == "" {
+		switch {
+		case networking.NodePort != 0:
+			networking.ServiceType = corev1.ServiceTypeNodePort
+		case d.ServiceType != "":
+			networking.ServiceType = d.ServiceType
+		default:
+			networking.ServiceType = DefaultServiceType
+		}
+	}
+
+	if spec.DeletionPolicy == "" {
+		spec.DeletionPolicy = DeletionPolicyDelete
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-app-stackit-cloud-v2-datalogger,mutating=false,failurePolicy=fail,sideEffects=None,groups=app.stackit.cloud,resources=dataloggers,verbs=create;update,versions=v2,name=vdatalogger-v2.kb.io,admissionReviewVersions=v1,matchPolicy=Equivalent
+
+// DataLoggerValidator rejects DataLogger specs the operator is not able to reconcile
+// +kubebuilder:object:generate=false
+type DataLoggerValidator struct{}
+
+var _ admission.CustomValidator = &DataLoggerValidator{}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *DataLoggerValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	dataLogger, err := toDataLogger(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, toInvalid(dataLogger, ValidateSpec(&dataLogger.Spec, field.NewPath("spec")))
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (v *DataLoggerValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldDataLogger, err := toDataLogger(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	dataLogger, err := toDataLogger(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	specPath := field.NewPath("spec")
+
+	allErrs := ValidateSpec(&dataLogger.Spec, specPath)
+
+	// the operator does not rename the children of a DataLogger, so a new name would orphan them
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(
+		dataLogger.Spec.CustomName, oldDataLogger.Spec.CustomName, specPath.Child("customName"),
+	)...)
+
+	return nil, toInvalid(dataLogger, allErrs)
+}
+
+// ValidateDelete implements admission.CustomValidator
+func (v *DataLoggerValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateSpec returns the field errors of a DataLogger spec
+func ValidateSpec(spec *DataLoggerSpec, specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	namePath := specPath.Child("customName")
+	if spec.CustomName == "" {
+		allErrs = append(allErrs, field.Required(namePath, "must be set"))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(spec.CustomName) {
+			allErrs = append(allErrs, field.Invalid(namePath, spec.CustomName, msg))
+		}
+	}
+
+	if spec.Workload.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(
+			specPath.Child("workload", "replicas"), spec.Workload.Replicas, "must be greater than or equal to 0",
+		))
+	}
+
+	networking := spec.Networking
+	networkingPath := specPath.Child("networking")
+
+	if networking.Port != 0 {
+		for _, msg := range validation.IsValidPortNum(int(networking.Port)) {
+			allErrs = append(allErrs, field.Invalid(networkingPath.Child("port"), networking.Port, msg))
+		}
+	}
+
+	targetPortPath := networkingPath.Child("targetPort")
+	if networking.TargetPort == 0 {
+		allErrs = append(allErrs, field.Required(targetPortPath, "must be set"))
+	} else {
+		for _, msg := range validation.IsValidPortNum(int(networking.TargetPort)) {
+			allErrs = append(allErrs, field.Invalid(targetPortPath, networking.TargetPort, msg))
+		}
+	}
+
+	nodePortPath := networkingPath.Child("nodePort")
+	if networking.NodePort != 0 && (networking.NodePort < NodePortMin || networking.NodePort > NodePortMax) {
+		allErrs = append(allErrs, field.Invalid(
+			nodePortPath, networking.NodePort,
+			fmt.Sprintf("must be between %d and %d, inclusive", NodePortMin, NodePortMax),
+		))
+	}
+
+	if networking.NodePort != 0 && networking.ServiceType == corev1.ServiceTypeClusterIP {
+		allErrs = append(allErrs, field.Forbidden(nodePortPath, "may not be used when serviceType is ClusterIP"))
+	}
+
+	if spec.Storage.Size != nil && spec.Storage.Size.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(
+			specPath.Child("storage", "size"), spec.Storage.Size.String(), "must be greater than 0",
+		))
+	}
+
+	return allErrs
+}
+
+func toDataLogger(obj runtime.Object) (*DataLogger, error) {
+	dataLogger, ok := obj.(*DataLogger)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a DataLogger but got a %T", obj))
+	}
+
+	return dataLogger, nil
+}
+
+func toInvalid(dataLogger *DataLogger, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("DataLogger").GroupKind(), dataLogger.Name, allErrs)
+}
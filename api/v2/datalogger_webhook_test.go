@@ -0,0 +1,249 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDataLoggerValidateCreate(t *testing.T) {
+	ctx := context.Background()
+
+	validator := &DataLoggerValidator{}
+
+	size := resource.MustParse("1Gi")
+	zeroSize := resource.MustParse("0")
+
+	tests := []struct {
+		name   string
+		spec   DataLoggerSpec
+		fields []string
+	}{
+		{
+			name: "valid",
+			spec: DataLoggerSpec{
+				CustomName: "datalogger-42",
+				Workload:   WorkloadSpec{Replicas: 1},
+				Networking: NetworkingSpec{Port: 8080, TargetPort: 80, NodePort: 32101},
+				Storage:    StorageSpec{Size: &size},
+			},
+			fields: nil,
+		},
+		{
+			name:   "invalid-custom-name",
+			spec:   DataLoggerSpec{CustomName: "DataLogger_42", Networking: NetworkingSpec{Port: 8080, TargetPort: 80}},
+			fields: []string{"spec.customName"},
+		},
+		{
+			name:   "missing-custom-name",
+			spec:   DataLoggerSpec{Networking: NetworkingSpec{Port: 8080, TargetPort: 80}},
+			fields: []string{"spec.customName"},
+		},
+		{
+			name: "node-port-out-of-range",
+			spec: DataLoggerSpec{
+				CustomName: "datalogger-42",
+				Networking: NetworkingSpec{Port: 8080, TargetPort: 80, NodePort: 8080},
+			},
+			fields: []string{"spec.networking.nodePort"},
+		},
+		{
+			name: "negative-replicas",
+			spec: DataLoggerSpec{
+				CustomName: "datalogger-42",
+				Workload:   WorkloadSpec{Replicas: -1},
+				Networking: NetworkingSpec{Port: 8080, TargetPort: 80},
+			},
+			fields: []string{"spec.workload.replicas"},
+		},
+		{
+			name: "node-port-with-cluster-ip",
+			spec: DataLoggerSpec{
+				CustomName: "datalogger-42",
+				Networking: NetworkingSpec{Port: 8080, TargetPort: 80, NodePort: 32101, ServiceType: corev1.ServiceTypeClusterIP},
+			},
+			fields: []string{"spec.networking.nodePort"},
+		},
+		{
+			name:   "missing-target-port",
+			spec:   DataLoggerSpec{CustomName: "datalogger-42", Networking: NetworkingSpec{Port: 8080}},
+			fields: []string{"spec.networking.targetPort"},
+		},
+		{
+			name:   "invalid-ports",
+			spec:   DataLoggerSpec{CustomName: "datalogger-42", Networking: NetworkingSpec{Port: 70000, TargetPort: -1}},
+			fields: []string{"spec.networking.port", "spec.networking.targetPort"},
+		},
+		{
+			name: "empty-storage",
+			spec: DataLoggerSpec{
+				CustomName: "datalogger-42",
+				Networking: NetworkingSpec{Port: 8080, TargetPort: 80},
+				Storage:    StorageSpec{Size: &zeroSize},
+			},
+			fields: []string{"spec.storage.size"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			dataLogger := &DataLogger{ObjectMeta: metav1.ObjectMeta{Name: test.name}, Spec: test.spec}
+
+			_, err := validator.ValidateCreate(ctx, dataLogger)
+			require.EqualValues(t, test.fields, invalidFields(t, err))
+		})
+	}
+}
+
+func TestDataLoggerValidateUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	validator := &DataLoggerValidator{}
+
+	oldDataLogger := &DataLogger{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample-42"},
+		Spec:       DataLoggerSpec{CustomName: "datalogger-42", Networking: NetworkingSpec{Port: 8080, TargetPort: 80}},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(spec *DataLoggerSpec)
+		fields []string
+	}{
+		{
+			name:   "scale",
+			mutate: func(spec *DataLoggerSpec) { spec.Workload.Replicas = 3 },
+			fields: nil,
+		},
+		{
+			name:   "rename",
+			mutate: func(spec *DataLoggerSpec) { spec.CustomName = "datalogger-43" },
+			fields: []string{"spec.customName"},
+		},
+		{
+			name:   "invalid-node-port",
+			mutate: func(spec *DataLoggerSpec) { spec.Networking.NodePort = 40000 },
+			fields: []string{"spec.networking.nodePort"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			dataLogger := oldDataLogger.DeepCopy()
+			test.mutate(&dataLogger.Spec)
+
+			_, err := validator.ValidateUpdate(ctx, oldDataLogger, dataLogger)
+			require.EqualValues(t, test.fields, invalidFields(t, err))
+		})
+	}
+}
+
+// invalidFields returns the field paths reported by an invalid error
+func invalidFields(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	require.True(t, apierrors.IsInvalid(err))
+
+	statusErr := &apierrors.StatusError{}
+	require.ErrorAs(t, err, &statusErr)
+
+	fields := make([]string, 0, len(statusErr.ErrStatus.Details.Causes))
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		fields = append(fields, cause.Field)
+	}
+
+	return fields
+}
+
+func TestDataLoggerDefault(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		defaulter *DataLoggerDefaulter
+		spec      DataLoggerSpec
+		want      DataLoggerSpec
+	}{
+		{
+			name:      "documented-defaults",
+			defaulter: &DataLoggerDefaulter{},
+			spec:      DataLoggerSpec{CustomName: "datalogger-42"},
+			want: DataLoggerSpec{
+				CustomName:     "datalogger-42",
+				Workload:       WorkloadSpec{Replicas: 1},
+				Networking:     NetworkingSpec{Port: 8080, TargetPort: 8080, ServiceType: corev1.ServiceTypeClusterIP},
+				DeletionPolicy: DeletionPolicyDelete,
+			},
+		},
+		{
+			name:      "operator-defaults",
+			defaulter: &DataLoggerDefaulter{Replicas: 2, Port: 9090, ServiceType: corev1.ServiceTypeLoadBalancer},
+			spec:      DataLoggerSpec{CustomName: "datalogger-42"},
+			want: DataLoggerSpec{
+				CustomName:     "datalogger-42",
+				Workload:       WorkloadSpec{Replicas: 2},
+				Networking:     NetworkingSpec{Port: 9090, TargetPort: 9090, ServiceType: corev1.ServiceTypeLoadBalancer},
+				DeletionPolicy: DeletionPolicyDelete,
+			},
+		},
+		{
+			name:      "node-port",
+			defaulter: &DataLoggerDefaulter{Replicas: 2, ServiceType: corev1.ServiceTypeLoadBalancer},
+			spec:      DataLoggerSpec{CustomName: "datalogger-42", Networking: NetworkingSpec{TargetPort: 80, NodePort: 32101}},
+			want: DataLoggerSpec{
+				CustomName:     "datalogger-42",
+				Workload:       WorkloadSpec{Replicas: 2},
+				Networking:     NetworkingSpec{Port: 8080, TargetPort: 80, NodePort: 32101, ServiceType: corev1.ServiceTypeNodePort},
+				DeletionPolicy: DeletionPolicyDelete,
+			},
+		},
+		{
+			name:      "keep-values",
+			defaulter: &DataLoggerDefaulter{Replicas: 2, Port: 9090},
+			spec: DataLoggerSpec{
+				CustomName:     "datalogger-42",
+				Workload:       WorkloadSpec{Replicas: 3},
+				Networking:     NetworkingSpec{Port: 8000, TargetPort: 80, ServiceType: corev1.ServiceTypeClusterIP},
+				DeletionPolicy: DeletionPolicyRetain,
+			},
+			want: DataLoggerSpec{
+				CustomName:     "datalogger-42",
+				Workload:       WorkloadSpec{Replicas: 3},
+				Networking:     NetworkingSpec{Port: 8000, TargetPort: 80, ServiceType: corev1.ServiceTypeClusterIP},
+				DeletionPolicy: DeletionPolicyRetain,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			dataLogger := &DataLogger{Spec: test.spec}
+
+			err := test.defaulter.Default(ctx, dataLogger)
+			require.Nil(t, err)
+			require.EqualValues(t, test.want, dataLogger.Spec)
+
+			_, err = (&DataLoggerValidator{}).ValidateCreate(ctx, dataLogger)
+			require.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command datalogger works with DataLogger manifests outside of the cluster
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	appv2 "stackit.cloud/datalogger/api/v2"
+	"stackit.cloud/datalogger/pkg/deployment"
+	"stackit.cloud/datalogger/pkg/render"
+)
+
+const usage = `Usage: datalogger <command> [flags]
+
+Commands:
+  render  Print the manifests the operator creates for the DataLoggers and namespaces of a file
+  diff    Compare the manifests the operator creates for a file with the objects in the cluster
+
+The exit code is 1 if diff found a drift, 2 on errors.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	drifted := false
+
+	switch os.Args[1] {
+	case "render":
+		err = runRender(os.Args[2:], os.Stdout)
+	case "diff":
+		drifted, err = runDiff(os.Args[2:], os.Stdout)
+	case "-h", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(2)
+	}
+
+	if drifted {
+		os.Exit(1)
+	}
+}
+
+// rendererFlags registers the defaults of the operator on the flag set, so that the
+// manifests are rendered as an operator started with the same flags creates them
+func rendererFlags(flags *flag.FlagSet) func() *render.Renderer {
+	var deploymentDefaults deployment.Defaults
+	var defaultImagePullPolicy, defaultServiceType string
+	var defaultReplicas, defaultPort int
+
+	flags.StringVar(&deploymentDefaults.Image, "default-image", deployment.DefaultImage,
+		"The container image used for dataLoggers that do not set one.")
+	flags.StringVar(&defaultImagePullPolicy, "default-image-pull-policy", "",
+		"The image pull policy used for dataLoggers that do not set one (Always, Never or IfNotPresent).")
+	flags.IntVar(&defaultReplicas, "default-replicas", int(appv2.DefaultReplicas),
+		"The replicas set for dataLoggers that do not set them.")
+	flags.IntVar(&defaultPort, "default-port", int(appv2.DefaultPort),
+		"The port set for dataLoggers that do not set one.")
+	flags.StringVar(&defaultServiceType, "default-service-type", string(appv2.DefaultServiceType),
+		"The service type set for dataLoggers without a node port.")
+
+	return func() *render.Renderer {
+		deploymentDefaults.ImagePullPolicy = corev1.PullPolicy(defaultImagePullPolicy)
+
+		return render.NewRenderer(deploymentDefaults, appv1.DataLoggerDefaulter{
+			Replicas:    int32(defaultReplicas),
+			Port:        int32(defaultPort),
+			ServiceType: corev1.ServiceType(defaultServiceType),
+		})
+	}
+}
+
+func runRender(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+
+	var filename string
+	flags.StringVar(&filename, "f", "", "The file with the DataLoggers and namespaces to render, - reads from stdin.")
+	newRenderer := rendererFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if filename == "" {
+		return fmt.Errorf("a file has to be passed with -f")
+	}
+
+	objs, err := readFile(filename)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := newRenderer().Render(objs)
+	if err != nil {
+		return err
+	}
+
+	return render.Write(out, rendered)
+}
+
+// runDiff prints the differences of the rendered manifests to the cluster and reports whether any object drifted
+func runDiff(args []string, out io.Writer) (bool, error) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+
+	var filename, kubeconfig, kubeContext string
+	flags.StringVar(&filename, "f", "", "The file with the DataLoggers and namespaces to compare, - reads from stdin.")
+	flags.StringVar(&kubeconfig, "kubeconfig", "", "The kubeconfig file. $KUBECONFIG and ~/.kube/config are used if empty.")
+	flags.StringVar(&kubeContext, "context", "", "The kubeconfig context to use. The current context is used if empty.")
+	newRenderer := rendererFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return false, err
+	}
+
+	if filename == "" {
+		return false, fmt.Errorf("a file has to be passed with -f")
+	}
+
+	objs, err := readFile(filename)
+	if err != nil {
+		return false, err
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, &clientcmd.ConfigOverrides{CurrentContext: kubeContext}).ClientConfig()
+	if err != nil {
+		return false, err
+	}
+
+	apiClient, err := client.New(restConfig, client.Options{Scheme: render.Scheme})
+	if err != nil {
+		return false, err
+	}
+
+	differences, err := newRenderer().Diff(context.Background(), apiClient, objs)
+	if err != nil {
+		return false, err
+	}
+
+	if err = render.WriteDiff(out, differences); err != nil {
+		return false, err
+	}
+
+	for _, difference := range differences {
+		if difference.Drifted() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// readFile decodes the objects of the file, - is stdin
+func readFile(filename string) ([]client.Object, error) {
+	if filename == "-" {
+		return render.Decode(os.Stdin)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return render.Decode(file)
+}
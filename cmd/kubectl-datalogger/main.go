@@ -0,0 +1,229 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command kubectl-datalogger is a kubectl plugin for the day-to-day operations on DataLoggers
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"stackit.cloud/datalogger/pkg/plugin"
+	"stackit.cloud/datalogger/pkg/render"
+)
+
+const usage = `Usage: kubectl datalogger <command> [flags]
+
+Commands:
+  list                  List the DataLoggers with their readiness, replicas and endpoint
+  describe NAME         Show a DataLogger with the tree of its children and their events
+  logs NAME             Print the logs of all pods of a DataLogger
+  port-forward NAME     Forward a local port to the service port of a DataLogger
+
+Flags of all commands:
+  --kubeconfig, --context, -n/--namespace
+`
+
+// options are the flags of the commands
+type options struct {
+	kubeconfig    string
+	kubeContext   string
+	namespace     string
+	allNamespaces bool
+	follow        bool
+	localPort     int
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command := os.Args[1]
+	if command == "-h" || command == "--help" || command == "help" {
+		fmt.Fprint(os.Stdout, usage)
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx, command, os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, args []string) error {
+	opts := options{}
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	flags.StringVar(&opts.kubeconfig, "kubeconfig", "", "The kubeconfig file. $KUBECONFIG and ~/.kube/config are used if empty.")
+	flags.StringVar(&opts.kubeContext, "context", "", "The kubeconfig context to use. The current context is used if empty.")
+	flags.StringVar(&opts.namespace, "namespace", "", "The namespace of the DataLogger. The namespace of the context is used if empty.")
+	flags.StringVar(&opts.namespace, "n", "", "Shorthand for --namespace.")
+
+	switch command {
+	case "list":
+		flags.BoolVar(&opts.allNamespaces, "all-namespaces", false, "List the DataLoggers of all namespaces.")
+		flags.BoolVar(&opts.allNamespaces, "A", false, "Shorthand for --all-namespaces.")
+	case "logs":
+		flags.BoolVar(&opts.follow, "follow", false, "Keep streaming the logs.")
+		flags.BoolVar(&opts.follow, "f", false, "Shorthand for --follow.")
+	case "port-forward":
+		flags.IntVar(&opts.localPort, "local-port", 0, "The local port, spec.port of the DataLogger if 0. Connections are forwarded to its target port.")
+	case "describe":
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", command, usage)
+	}
+
+	names, err := parseInterspersed(flags, args)
+	if err != nil {
+		return err
+	}
+
+	clientConfig := kubeconfig(opts)
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	if opts.namespace == "" {
+		if opts.namespace, _, err = clientConfig.Namespace(); err != nil {
+			return err
+		}
+	}
+
+	apiClient, err := client.New(restConfig, client.Options{Scheme: render.Scheme})
+	if err != nil {
+		return err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	commands := plugin.NewPlugin(apiClient, clientset, os.Stdout)
+
+	if command == "list" {
+		namespace := opts.namespace
+		if opts.allNamespaces {
+			namespace = ""
+		}
+
+		return commands.List(ctx, namespace)
+	}
+
+	if len(names) != 1 {
+		return fmt.Errorf("%s needs the name of a DataLogger", command)
+	}
+
+	key := types.NamespacedName{Namespace: opts.namespace, Name: names[0]}
+
+	switch command {
+	case "describe":
+		return commands.Describe(ctx, key)
+	case "logs":
+		return commands.Logs(ctx, key, opts.follow)
+	default:
+		return portForward(ctx, commands, restConfig, clientset, key, opts.localPort)
+	}
+}
+
+// kubeconfig loads the kubeconfig with the standard rules of kubectl
+func kubeconfig(opts options) clientcmd.ClientConfig {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = opts.kubeconfig
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, &clientcmd.ConfigOverrides{CurrentContext: opts.kubeContext})
+}
+
+// parseInterspersed parses the flags wherever they are placed between the names, as kubectl does
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	names := []string{}
+
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = flags.Args()
+		if len(args) == 0 {
+			return names, nil
+		}
+
+		names = append(names, args[0])
+		args = args[1:]
+	}
+}
+
+// portForward forwards the local port to the port the service of the dataLogger targets on one of its ready pods,
+// until ctx is done
+func portForward(
+	ctx context.Context,
+	commands *plugin.Plugin,
+	restConfig *rest.Config,
+	clientset kubernetes.Interface,
+	key types.NamespacedName,
+	localPort int,
+) error {
+	target, err := commands.PortForwardTarget(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	pod := target.Pod
+
+	if localPort == 0 {
+		localPort = int(target.Port)
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return err
+	}
+
+	url := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward").URL()
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	forwarder, err := portforward.New(dialer, []string{fmt.Sprintf("%d:%d", localPort, target.TargetPort)},
+		ctx.Done(), make(chan struct{}), os.Stdout, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Forwarding to pod %s of DataLogger %s\n", pod.Name, key)
+
+	return forwarder.ForwardPorts()
+}
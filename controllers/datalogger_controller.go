@@ -18,14 +18,19 @@ package controllers
 
 import (
 	"context"
-	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/datalogger"
+	"stackit.cloud/datalogger/pkg/metrics"
+	"stackit.cloud/datalogger/pkg/requeue"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -37,14 +42,16 @@ type DataLoggerReconciler struct {
 	apiClient pkg.APIClientOperator
 	operator  DataLoggerReconcileOperator
 	scheme    *runtime.Scheme
+	backoff   requeue.Options
 }
 
 func NewDataLoggerReconciler(
 	apiClient pkg.APIClientOperator,
 	operator DataLoggerReconcileOperator,
 	scheme *runtime.Scheme,
+	backoff requeue.Options,
 ) *DataLoggerReconciler {
-	return &DataLoggerReconciler{apiClient: apiClient, scheme: scheme, operator: operator}
+	return &DataLoggerReconciler{apiClient: apiClient, scheme: scheme, operator: operator, backoff: backoff}
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -64,24 +71,25 @@ func (r *DataLoggerReconciler) Reconcile(
 	)
 
 	if err != nil {
-		if !errors.IsNotFound(err) {
-			logger.Error(err, "unable to fetch dataLogger CRD", "datalogger controller", req.Namespace)
+		// The dataLogger was deleted, there is nothing left to reconcile
+		if errors.IsNotFound(err) {
+			metrics.ForgetDataLogger(req.NamespacedName)
+			return ctrl.Result{}, nil
 		}
 
-		return ctrl.Result{
-			Requeue:      true,
-			RequeueAfter: RequeueTime * time.Second,
-		}, client.IgnoreNotFound(err)
+		logger.Error(err, "unable to fetch dataLogger CRD", "name", req.Name, "namespace", req.Namespace)
+
+		return r.backoff.Result(err)
 	}
 
 	err = r.operator.Reconcile(ctx, req, dataLogger)
+	metrics.SetPhase(req.NamespacedName, datalogger.Phase(dataLogger))
+
 	if err != nil {
-		logger.Error(err, "unable to reconcile dataLogger CRD", "namespace", req.Namespace)
+		logger.Error(err, "unable to reconcile dataLogger CRD",
+			"name", req.Name, "namespace", req.Namespace, "class", requeue.ClassOf(err))
 
-		return ctrl.Result{
-			Requeue:      true,
-			RequeueAfter: RequeueTime * time.Second,
-		}, err
+		return r.backoff.Result(err)
 	}
 
 	return ctrl.Result{Requeue: false}, nil
@@ -90,7 +98,9 @@ func (r *DataLoggerReconciler) Reconcile(
 // SetupWithManager sets up the controller with the Manager.
 func (r *DataLoggerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&appv1.DataLogger{}).
-		Owns(&corev1.Namespace{}).
+		For(&appv1.DataLogger{}, builder.WithPredicates(DataLoggerPredicate())).
+		Owns(&appsv1.Deployment{}, builder.WithPredicates(DeploymentPredicate())).
+		Owns(&corev1.Service{}, builder.WithPredicates(ServicePredicate())).
+		WithOptions(controller.Options{RateLimiter: requeue.NewRateLimiter(r.backoff)}).
 		Complete(r)
 }
@@ -6,11 +6,12 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
-	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,6 +19,7 @@ import (
 	appv1 "stackit.cloud/datalogger/api/v1"
 	"stackit.cloud/datalogger/controllers/mock"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/requeue"
 )
 
 func TestDataLoggerControllerWithNoErrors(t *testing.T) {
@@ -30,7 +32,7 @@ func TestDataLoggerControllerWithNoErrors(t *testing.T) {
 
 	schema := &runtime.Scheme{}
 
-	reconciler := NewDataLoggerReconciler(apiClient, operator, schema)
+	reconciler := NewDataLoggerReconciler(apiClient, operator, schema, requeue.DefaultOptions())
 
 	tests := []struct {
 		name        string
@@ -92,7 +94,7 @@ func TestDataLoggerControllerWithErrors(t *testing.T) {
 
 	schema := &runtime.Scheme{}
 
-	reconciler := NewDataLoggerReconciler(apiClient, operator, schema)
+	reconciler := NewDataLoggerReconciler(apiClient, operator, schema, requeue.DefaultOptions())
 
 	tests := []struct {
 		name        string
@@ -108,7 +110,7 @@ func TestDataLoggerControllerWithErrors(t *testing.T) {
 			errorValue1: errors.New("unknown api error"),
 			errorValue2: nil,
 			times:       1,
-			want:        ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			want:        ctrl.Result{},
 		},
 		{
 			name:        "DataLoggerController",
@@ -116,7 +118,7 @@ func TestDataLoggerControllerWithErrors(t *testing.T) {
 			errorValue1: nil,
 			errorValue2: errors.New("unknown api error"),
 			times:       1,
-			want:        ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			want:        ctrl.Result{},
 		},
 	}
 
@@ -144,3 +146,95 @@ func TestDataLoggerControllerWithErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestDataLoggerControllerErrorClasses(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+
+	operator := mock.NewMockDataLoggerReconcileOperator(mockCtrl)
+	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+
+	backoff := requeue.DefaultOptions()
+	backoff.Jitter = 0
+
+	reconciler := NewDataLoggerReconciler(apiClient, operator, &runtime.Scheme{}, backoff)
+
+	groupResource := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	tests := []struct {
+		name     string
+		err      error
+		want     ctrl.Result
+		wantErr  bool
+		terminal bool
+	}{
+		{
+			name:    "transient",
+			err:     errors.New("connection refused"),
+			want:    ctrl.Result{},
+			wantErr: true,
+		},
+		{
+			name:    "conflict",
+			err:     apierrors.NewConflict(groupResource, "datalogger-42", errors.New("object was modified")),
+			want:    ctrl.Result{RequeueAfter: backoff.BaseDelay},
+			wantErr: false,
+		},
+		{
+			name:     "terminal",
+			err:      apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "datalogger-42", nil),
+			want:     ctrl.Result{},
+			wantErr:  true,
+			terminal: true,
+		},
+		{
+			name:    "waiting",
+			err:     requeue.Waiting(errors.New("namespace is not created yet")),
+			want:    ctrl.Result{RequeueAfter: backoff.WaitDelay},
+			wantErr: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "my-namespace1", Name: test.name}}
+			crdObject := &appv1.DataLogger{}
+
+			apiClient.EXPECT().Get(
+				ctx, client.ObjectKey{Name: test.name, Namespace: "my-namespace1"}, crdObject,
+			).Times(1).Return(nil)
+
+			operator.EXPECT().Reconcile(ctx, req, crdObject).Times(1).Return(test.err)
+
+			actual, err := reconciler.Reconcile(ctx, req)
+			require.EqualValues(t, test.want, actual)
+			require.EqualValues(t, test.wantErr, err != nil)
+			require.EqualValues(t, test.terminal, errors.Is(err, reconcile.TerminalError(nil)))
+		})
+	}
+}
+
+func TestDataLoggerControllerNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+
+	operator := mock.NewMockDataLoggerReconcileOperator(mockCtrl)
+	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+
+	reconciler := NewDataLoggerReconciler(apiClient, operator, &runtime.Scheme{}, requeue.DefaultOptions())
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "my-namespace1", Name: "datalogger-sample"}}
+
+	apiClient.EXPECT().Get(
+		ctx, req.NamespacedName, &appv1.DataLogger{},
+	).Times(1).Return(apierrors.NewNotFound(schema.GroupResource{Resource: "dataloggers"}, req.Name))
+
+	actual, err := reconciler.Reconcile(ctx, req)
+	require.Nil(t, err)
+	require.EqualValues(t, ctrl.Result{}, actual)
+}
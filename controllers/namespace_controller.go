@@ -4,15 +4,15 @@ package controllers
 
 import (
 	"context"
-	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/requeue"
 )
 
 // NamespaceReconciler reconciles a Namespace object
@@ -20,14 +20,16 @@ type NamespaceReconciler struct {
 	apiClient pkg.APIClientOperator
 	ns        pkg.NamespaceOperator
 	scheme    *runtime.Scheme
+	backoff   requeue.Options
 }
 
 func NewNamespaceReconciler(
 	apiClient pkg.APIClientOperator,
 	scheme *runtime.Scheme,
 	ns pkg.NamespaceOperator,
+	backoff requeue.Options,
 ) *NamespaceReconciler {
-	return &NamespaceReconciler{apiClient: apiClient, scheme: scheme, ns: ns}
+	return &NamespaceReconciler{apiClient: apiClient, scheme: scheme, ns: ns, backoff: backoff}
 }
 
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
@@ -37,14 +39,15 @@ func (r *NamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	err := r.ns.Reconcile(ctx, req, r.apiClient)
 	if err != nil {
-		if !errors.IsNotFound(err) {
-			logger.Error(err, "unable to fetch dataLogger CRD namespace", req.Name, req.Namespace)
+		// The namespace was deleted, there is nothing left to reconcile
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
 		}
 
-		return ctrl.Result{
-			Requeue:      true,
-			RequeueAfter: RequeueTime * time.Second,
-		}, client.IgnoreNotFound(err)
+		logger.Error(err, "unable to fetch dataLogger CRD namespace",
+			"name", req.Name, "namespace", req.Namespace, "class", requeue.ClassOf(err))
+
+		return r.backoff.Result(err)
 	}
 
 	return ctrl.Result{Requeue: false}, nil
@@ -53,5 +56,6 @@ func (r *NamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 func (r *NamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Namespace{}).
+		WithOptions(controller.Options{RateLimiter: requeue.NewRateLimiter(r.backoff)}).
 		Complete(r)
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
-	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -15,6 +14,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/requeue"
 )
 
 func TestNamespaceControllerWithNoErrors(t *testing.T) {
@@ -27,7 +27,7 @@ func TestNamespaceControllerWithNoErrors(t *testing.T) {
 
 	schema := &runtime.Scheme{}
 
-	reconciler := NewNamespaceReconciler(apiClient, schema, operator)
+	reconciler := NewNamespaceReconciler(apiClient, schema, operator, requeue.DefaultOptions())
 
 	tests := []struct {
 		name        string
@@ -83,7 +83,7 @@ func TestNamespaceControllerWithErrors(t *testing.T) {
 
 	schema := &runtime.Scheme{}
 
-	reconciler := NewNamespaceReconciler(apiClient, schema, operator)
+	reconciler := NewNamespaceReconciler(apiClient, schema, operator, requeue.DefaultOptions())
 
 	tests := []struct {
 		name        string
@@ -107,7 +107,7 @@ func TestNamespaceControllerWithErrors(t *testing.T) {
 			errorValue1: errors.New("reconcile error 1"),
 			errorValue2: nil,
 			times:       1,
-			want:        ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			want:        ctrl.Result{},
 		},
 	}
 
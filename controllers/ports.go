@@ -7,8 +7,6 @@ import (
 	appv1 "stackit.cloud/datalogger/api/v1"
 )
 
-const RequeueTime = 30
-
 type DataLoggerReconcileOperator interface {
 	Reconcile(ctx context.Context, req ctrl.Request, dataLogger *appv1.DataLogger) error
 }
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// DataLoggerPredicate lets spec (generation) and label changes of a dataLogger through,
+// so that the status updates of the operator itself do not trigger a reconcile
+func DataLoggerPredicate() predicate.Predicate {
+	return predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})
+}
+
+// DeploymentPredicate lets spec, label and annotation changes of an owned Deployment through,
+// and the status changes the dataLogger status is computed from
+func DeploymentPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.Funcs{UpdateFunc: deploymentReadinessChanged},
+	)
+}
+
+// ServicePredicate lets spec, label and annotation changes of an owned Service through.
+// Services have no generation, so their spec is compared directly.
+func ServicePredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.LabelChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.Funcs{UpdateFunc: serviceSpecChanged},
+	)
+}
+
+// deploymentReadinessChanged reports whether the ready replicas or the Available condition changed
+func deploymentReadinessChanged(e event.UpdateEvent) bool {
+	oldDeployment, ok := e.ObjectOld.(*appsv1.Deployment)
+	if !ok {
+		return false
+	}
+
+	newDeployment, ok := e.ObjectNew.(*appsv1.Deployment)
+	if !ok {
+		return false
+	}
+
+	if oldDeployment.Status.ReadyReplicas != newDeployment.Status.ReadyReplicas {
+		return true
+	}
+
+	return availableStatus(oldDeployment) != availableStatus(newDeployment)
+}
+
+// availableStatus returns the status of the Available condition of the deployment
+func availableStatus(deployment *appsv1.Deployment) corev1.ConditionStatus {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable {
+			return condition.Status
+		}
+	}
+
+	return corev1.ConditionUnknown
+}
+
+// serviceSpecChanged reports whether the spec of the service changed
+func serviceSpecChanged(e event.UpdateEvent) bool {
+	oldService, ok := e.ObjectOld.(*corev1.Service)
+	if !ok {
+		return false
+	}
+
+	newService, ok := e.ObjectNew.(*corev1.Service)
+	if !ok {
+		return false
+	}
+
+	return !equality.Semantic.DeepEqual(oldService.Spec, newService.Spec)
+}
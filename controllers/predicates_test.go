@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	appv1 "stackit.cloud/datalogger/api/v1"
+)
+
+func TestPredicatesUpdate(t *testing.T) {
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample", Generation: 1},
+		Spec:       appv1.DataLoggerSpec{CustomName: "datalogger-42", Replicas: 1},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-42", Generation: 1},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: 0,
+			Conditions:    []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse}},
+		},
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-42"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+	}
+
+	tests := []struct {
+		name      string
+		predicate predicate.Predicate
+		old       client.Object
+		update    func(obj client.Object)
+		want      bool
+	}{
+		{
+			name:      "datalogger-status",
+			predicate: DataLoggerPredicate(),
+			old:       dataLogger,
+			update: func(obj client.Object) {
+				obj.(*appv1.DataLogger).Status.ReadyReplicas = 1
+			},
+			want: false,
+		},
+		{
+			name:      "datalogger-spec",
+			predicate: DataLoggerPredicate(),
+			old:       dataLogger,
+			update: func(obj client.Object) {
+				obj.SetGeneration(2)
+			},
+			want: true,
+		},
+		{
+			name:      "datalogger-labels",
+			predicate: DataLoggerPredicate(),
+			old:       dataLogger,
+			update: func(obj client.Object) {
+				obj.SetLabels(map[string]string{"app.kubernetes.io/name": "datalogger"})
+			},
+			want: true,
+		},
+		{
+			name:      "deployment-status",
+			predicate: DeploymentPredicate(),
+			old:       deployment,
+			update: func(obj client.Object) {
+				obj.(*appsv1.Deployment).Status.ObservedGeneration = 1
+				obj.(*appsv1.Deployment).Status.UpdatedReplicas = 1
+			},
+			want: false,
+		},
+		{
+			name:      "deployment-ready",
+			predicate: DeploymentPredicate(),
+			old:       deployment,
+			update: func(obj client.Object) {
+				obj.(*appsv1.Deployment).Status.ReadyReplicas = 1
+			},
+			want: true,
+		},
+		{
+			name:      "deployment-available",
+			predicate: DeploymentPredicate(),
+			old:       deployment,
+			update: func(obj client.Object) {
+				obj.(*appsv1.Deployment).Status.Conditions[0].Status = corev1.ConditionTrue
+			},
+			want: true,
+		},
+		{
+			name:      "deployment-spec",
+			predicate: DeploymentPredicate(),
+			old:       deployment,
+			update: func(obj client.Object) {
+				obj.SetGeneration(2)
+			},
+			want: true,
+		},
+		{
+			name:      "service-status",
+			predicate: ServicePredicate(),
+			old:       service,
+			update: func(obj client.Object) {
+				obj.(*corev1.Service).Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			},
+			want: false,
+		},
+		{
+			name:      "service-spec",
+			predicate: ServicePredicate(),
+			old:       service,
+			update: func(obj client.Object) {
+				obj.(*corev1.Service).Spec.Type = corev1.ServiceTypeNodePort
+			},
+			want: true,
+		},
+		{
+			name:      "service-annotations",
+			predicate: ServicePredicate(),
+			old:       service,
+			update: func(obj client.Object) {
+				obj.SetAnnotations(map[string]string{"app.stackit.cloud/last-applied-hash": "edited"})
+			},
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			updated := test.old.DeepCopyObject().(client.Object)
+			test.update(updated)
+
+			got := test.predicate.Update(event.UpdateEvent{ObjectOld: test.old, ObjectNew: updated})
+			require.EqualValues(t, test.want, got)
+		})
+	}
+}
+
+func TestPredicatesDelete(t *testing.T) {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "datalogger-42"}}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "datalogger-42"}}
+
+	require.True(t, DeploymentPredicate().Delete(event.DeleteEvent{Object: deployment}))
+	require.True(t, ServicePredicate().Delete(event.DeleteEvent{Object: service}))
+}
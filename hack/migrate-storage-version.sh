@@ -0,0 +1,24 @@
+#!/usr/bin/env bash
+
+# Migrates the stored DataLoggers to the storage version v2 after the operator was deployed with
+# config/default on a cluster that stored them as v1, e.g. installed with `make install`.
+#
+# A DataLogger read and written back unchanged is converted by the conversion webhook and stored as
+# v2 again, so all of them are replaced once. v1 is then dropped from status.storedVersions of the
+# CRD, which marks the migration as done and would let a later release stop serving v1.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+KUBECTL=${KUBECTL:-kubectl}
+CRD=dataloggers.app.stackit.cloud
+
+if [ -n "$("${KUBECTL}" get "dataloggers.v2.app.stackit.cloud" --all-namespaces -o name)" ]; then
+  "${KUBECTL}" get "dataloggers.v2.app.stackit.cloud" --all-namespaces -o json | "${KUBECTL}" replace -f -
+fi
+
+"${KUBECTL}" patch customresourcedefinition "${CRD}" --subresource=status --type=merge \
+  -p '{"status":{"storedVersions":["v2"]}}'
+
+"${KUBECTL}" get customresourcedefinition "${CRD}" -o jsonpath='{.status.storedVersions}{"\n"}'
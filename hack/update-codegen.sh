@@ -0,0 +1,44 @@
+#!/usr/bin/env bash
+
+# Generates the clientset, informers and listers of the app.stackit.cloud/v1 API in pkg/generated.
+#
+# The generators name the client packages after the directory above the version and read a
+# directory named api as the core group, so api/v1 is linked as api/app/v1 while they run and
+# the imports are pointed back to api/v1 afterwards.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+MODULE=stackit.cloud/datalogger
+OUTPUT_PACKAGE=${MODULE}/pkg/generated
+HEADER=hack/boilerplate.go.txt
+
+CLIENT_GEN=${CLIENT_GEN:-bin/client-gen}
+LISTER_GEN=${LISTER_GEN:-bin/lister-gen}
+INFORMER_GEN=${INFORMER_GEN:-bin/informer-gen}
+
+cd "$(dirname "${BASH_SOURCE[0]}")/.."
+
+mkdir -p api/app
+ln -sfn ../v1 api/app/v1
+trap 'rm -rf api/app' EXIT
+
+rm -rf pkg/generated
+
+"${CLIENT_GEN}" --go-header-file "${HEADER}" --output-base . --trim-path-prefix "${MODULE}" \
+  --input-base "${MODULE}/api" --input app/v1 \
+  --clientset-name versioned --output-package "${OUTPUT_PACKAGE}/clientset"
+
+"${LISTER_GEN}" --go-header-file "${HEADER}" --output-base . --trim-path-prefix "${MODULE}" \
+  --input-dirs "${MODULE}/api/app/v1" \
+  --output-package "${OUTPUT_PACKAGE}/listers"
+
+"${INFORMER_GEN}" --go-header-file "${HEADER}" --output-base . --trim-path-prefix "${MODULE}" \
+  --input-dirs "${MODULE}/api/app/v1" \
+  --versioned-clientset-package "${OUTPUT_PACKAGE}/clientset/versioned" \
+  --listers-package "${OUTPUT_PACKAGE}/listers" \
+  --output-package "${OUTPUT_PACKAGE}/informers"
+
+grep -rl "${MODULE}/api/app/v1" pkg/generated | xargs sed -i.bak "s#${MODULE}/api/app/v1#${MODULE}/api/v1#g"
+find pkg/generated -name '*.bak' -delete
@@ -22,6 +22,6 @@ func NewServiceReference() *ServiceReference {
 	return &ServiceReference{}
 }
 
-func (s ServiceReference) IsControlledBy(obj metav1.Object, owner metav1.Object) bool {
-	return metav1.IsControlledBy(obj, owner)
+func (s ServiceReference) SetControllerReference(owner, controlled metav1.Object, scheme *runtime.Scheme) error {
+	return controllerutil.SetControllerReference(owner, controlled, scheme)
 }
@@ -17,25 +17,35 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"flag"
 	"os"
-	"path/filepath"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"stackit.cloud/datalogger/internal"
 
 	"stackit.cloud/datalogger/pkg/datalogger"
 	"stackit.cloud/datalogger/pkg/deployment"
+	"stackit.cloud/datalogger/pkg/drift"
+	"stackit.cloud/datalogger/pkg/metrics"
 	"stackit.cloud/datalogger/pkg/namespace"
+	"stackit.cloud/datalogger/pkg/registry"
+	"stackit.cloud/datalogger/pkg/requeue"
 	"stackit.cloud/datalogger/pkg/service"
 
 	appv1 "stackit.cloud/datalogger/api/v1"
+	appv2 "stackit.cloud/datalogger/api/v2"
 	"stackit.cloud/datalogger/controllers"
 	// +kubebuilder:scaffold:imports
 )
@@ -49,14 +59,16 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(appv1.AddToScheme(scheme))
+	utilruntime.Must(appv2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
-// CustomOptions extends ctrl.Options with MetricsBindAddress and Port
+// CustomOptions extends ctrl.Options with the flags of the metrics and webhook servers
 type CustomOptions struct {
 	ctrl.Options
 
 	MetricsBindAddress string
+	SecureMetrics      bool
 	Port               int
 }
 
@@ -64,13 +76,53 @@ func main() {
 	var customOpts CustomOptions
 	var enableLeaderElection bool
 	var probeAddr string
+	var deploymentDefaults deployment.Defaults
+	var defaultImagePullPolicy string
+	var specDefaulter appv2.DataLoggerDefaulter
+	var defaultServiceType string
+	var defaultReplicas, defaultPort int
+	var finalizeTimeout time.Duration
+	var kubeContext string
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	backoff := requeue.DefaultOptions()
 
 	flag.StringVar(&customOpts.MetricsBindAddress, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
-	flag.IntVar(&customOpts.Port, "port", 9443, "The port the controller manager serves on.")
+	flag.BoolVar(&customOpts.SecureMetrics, "metrics-secure", false,
+		"Serve the metrics over https, only to clients authenticated with TokenReviews and authorized with "+
+			"SubjectAccessReviews. Replaces the kube-rbac-proxy sidecar.")
+	flag.IntVar(&customOpts.Port, "port", 9443, "The port the webhook server serves on.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&deploymentDefaults.Image, "default-image", deployment.DefaultImage,
+		"The container image used for dataLoggers that do not set one.")
+	flag.StringVar(&defaultImagePullPolicy, "default-image-pull-policy", "",
+		"The image pull policy used for dataLoggers that do not set one (Always, Never or IfNotPresent).")
+	flag.IntVar(&defaultReplicas, "default-replicas", int(appv2.DefaultReplicas),
+		"The replicas the defaulting webhook sets for dataLoggers that do not set them.")
+	flag.IntVar(&defaultPort, "default-port", int(appv2.DefaultPort),
+		"The port the defaulting webhook sets for dataLoggers that do not set one.")
+	flag.StringVar(&defaultServiceType, "default-service-type", string(appv2.DefaultServiceType),
+		"The service type the defaulting webhook sets for dataLoggers without a node port.")
+	flag.DurationVar(&backoff.BaseDelay, "backoff-base-delay", backoff.BaseDelay,
+		"The delay before a failed reconcile is retried the first time and before every retry of a conflict. "+
+			"It doubles with every further transient failure.")
+	flag.DurationVar(&backoff.MaxDelay, "backoff-max-delay", backoff.MaxDelay,
+		"The maximum delay between the retries of a failed reconcile.")
+	flag.DurationVar(&backoff.WaitDelay, "dependency-requeue-after", backoff.WaitDelay,
+		"The delay before a reconcile waiting on a missing object is retried.")
+	flag.Float64Var(&backoff.Jitter, "backoff-jitter", backoff.Jitter,
+		"The fraction by which the retry delays are randomized.")
+	flag.DurationVar(&finalizeTimeout, "finalize-timeout", datalogger.DefaultFinalizeTimeout,
+		"The time after which a deleted dataLogger still waiting for a child is reported as FinalizeStuck.")
+	flag.StringVar(&kubeContext, "context", "",
+		"The kubeconfig context to use. The current context is used if empty.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 20,
+		"The maximum queries per second of the client to the API server.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 30,
+		"The maximum burst of queries of the client to the API server.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -79,57 +131,97 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	deploymentDefaults.ImagePullPolicy = corev1.PullPolicy(defaultImagePullPolicy)
+	specDefaulter.Replicas = int32(defaultReplicas)
+	specDefaulter.Port = int32(defaultPort)
+	specDefaulter.ServiceType = corev1.ServiceType(defaultServiceType)
+
+	metricsOptions := metricsserver.Options{
+		BindAddress:   customOpts.MetricsBindAddress,
+		SecureServing: customOpts.SecureMetrics,
+	}
+	if customOpts.SecureMetrics {
+		metricsOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
+	}
+
 	customOpts.Options = ctrl.Options{
 		Scheme:                 scheme,
+		Metrics:                metricsOptions,
+		WebhookServer:          webhook.NewServer(webhook.Options{Port: customOpts.Port}),
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "dfc9ea2a.stackit.cloud",
 	}
 
-	// Load kubeconfig from the specified path or the default path
-	kubeconfigPath := os.Getenv("KUBECONFIG")
-	if kubeconfigPath == "" {
-		kubeconfigPath = filepath.Join(os.Getenv("HOME"), ".kube", "config")
-	}
-
-	kubeconfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	// Load the kubeconfig from --kubeconfig, $KUBECONFIG, the in-cluster service account or ~/.kube/config
+	kubeconfig, err := config.GetConfigWithContext(kubeContext)
 	if err != nil {
 		setupLog.Error(err, "unable to load kubeconfig")
 		os.Exit(1)
 	}
 
+	kubeconfig.QPS = float32(kubeAPIQPS)
+	kubeconfig.Burst = kubeAPIBurst
+
 	mgr, err := ctrl.NewManager(kubeconfig, customOpts.Options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	recorder := mgr.GetEventRecorderFor("datalogger-controller")
+
+	driftDetector := drift.NewDetector(recorder)
+
 	deploymentReference := internal.NewDeploymentReference()
 
-	newDeployment := deployment.NewDeployment(deploymentReference)
+	newDeployment := deployment.NewDeployment(deploymentReference, driftDetector, recorder, deploymentDefaults)
 
 	serviceReference := internal.NewServiceReference()
 
-	newService := service.NewService(serviceReference)
+	newService := service.NewService(serviceReference, driftDetector, recorder)
+
+	// Further components are registered here, after the steps they depend on
+	steps := registry.NewRegistry()
+	err = errors.Join(
+		steps.Register(metrics.ReconcilerDeployment, newDeployment),
+		steps.Register(metrics.ReconcilerService, newService, metrics.ReconcilerDeployment),
+	)
+	if err == nil {
+		_, err = steps.Order()
+	}
+
+	if err != nil {
+		setupLog.Error(err, "unable to register the sub-reconcilers")
+		os.Exit(1)
+	}
 
-	dataLoggerReconciler := datalogger.NewReconciler(mgr.GetClient(), newDeployment, newService)
+	dataLoggerReconciler := datalogger.NewReconciler(
+		mgr.GetClient(), steps, driftDetector, recorder, finalizeTimeout)
 
 	err = controllers.NewDataLoggerReconciler(
-		mgr.GetClient(), dataLoggerReconciler, mgr.GetScheme()).SetupWithManager(mgr)
+		mgr.GetClient(), dataLoggerReconciler, mgr.GetScheme(), backoff).SetupWithManager(mgr)
 
 	if err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "dataLogger")
 		os.Exit(1)
 	}
 
-	namespaceOperator := namespace.NewNamespaceReconciler(setupLog)
+	namespaceOperator := namespace.NewNamespaceReconciler(setupLog, recorder)
 
-	err = controllers.NewNamespaceReconciler(mgr.GetClient(), mgr.GetScheme(), namespaceOperator).SetupWithManager(mgr)
+	err = controllers.NewNamespaceReconciler(
+		mgr.GetClient(), mgr.GetScheme(), namespaceOperator, backoff).SetupWithManager(mgr)
 	if err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Namespace")
 		os.Exit(1)
 	}
 
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&appv2.DataLogger{}).SetupWebhookWithManager(mgr, &specDefaulter); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DataLogger")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
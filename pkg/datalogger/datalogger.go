@@ -2,14 +2,18 @@ package datalogger
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	appv1 "stackit.cloud/datalogger/api/v1"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/metrics"
+	"stackit.cloud/datalogger/pkg/registry"
 )
 
 // ClusterFinalizer is the name used for our finalizer in the dataLogger resource
@@ -17,120 +21,174 @@ import (
 // accordingly
 const ClusterFinalizer = "finalizer.stackit.cloud/datalogger"
 
+// DefaultFinalizeTimeout is the time after which a deletion that still waits for a child is reported as stuck
+const DefaultFinalizeTimeout = 5 * time.Minute
+
 type Reconciler struct {
-	apiClient  pkg.APIClientOperator
-	deployment pkg.DeploymentOperator
-	service    pkg.ServiceOperator
+	apiClient       pkg.APIClientOperator
+	steps           *registry.Registry
+	drift           pkg.DriftOperator
+	recorder        pkg.EventRecorderOperator
+	finalizeTimeout time.Duration
 }
 
 func NewReconciler(
 	apiClient pkg.APIClientOperator,
-	deployment pkg.DeploymentOperator,
-	service pkg.ServiceOperator,
+	steps *registry.Registry,
+	drift pkg.DriftOperator,
+	recorder pkg.EventRecorderOperator,
+	finalizeTimeout time.Duration,
 ) *Reconciler {
-	return &Reconciler{apiClient: apiClient, deployment: deployment, service: service}
+	if finalizeTimeout <= 0 {
+		finalizeTimeout = DefaultFinalizeTimeout
+	}
+
+	return &Reconciler{
+		apiClient:       apiClient,
+		steps:           steps,
+		drift:           drift,
+		recorder:        recorder,
+		finalizeTimeout: finalizeTimeout,
+	}
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request, dataLogger *appv1.DataLogger) error {
-	if !dataLogger.ObjectMeta.DeletionTimestamp.IsZero() && len(dataLogger.ObjectMeta.Finalizers) > 0 {
-		if dataLogger.ObjectMeta.Finalizers[0] == ClusterFinalizer {
-			dataLogger.ObjectMeta.Finalizers = nil
-
-			err := r.Finalize(ctx, dataLogger, req)
-			if err != nil {
-				return err
-			}
+	if !dataLogger.ObjectMeta.DeletionTimestamp.IsZero() {
+		// Other finalizers are left to their owners
+		if !controllerutil.ContainsFinalizer(dataLogger, ClusterFinalizer) {
+			return nil
 		}
 
-		return nil
-	}
+		reason, message := finalizingCondition(DeletionPolicy(dataLogger))
+
+		err := r.patchStatus(ctx, dataLogger, func() {
+			setCondition(dataLogger, appv1.ConditionFinalizing, metav1.ConditionTrue, reason, message)
+		})
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+
+		err = r.Finalize(ctx, dataLogger)
+		metrics.ObserveReconcile(metrics.ReconcilerFinalize, start, err)
 
-	err := r.deployment.Reconcile(ctx, req, r.apiClient)
-	if err != nil {
 		return err
 	}
 
-	err = r.service.Reconcile(ctx, req, r.apiClient)
+	// The finalizer is added before any child is created, so that none is left behind
+	if !controllerutil.ContainsFinalizer(dataLogger, ClusterFinalizer) {
+		err := r.patchFinalizers(ctx, dataLogger, func() { controllerutil.AddFinalizer(dataLogger, ClusterFinalizer) })
+		if err != nil {
+			return err
+		}
+	}
+
+	scope := pkg.NewScope(dataLogger)
+
+	outcomes, err := r.steps.Run(ctx, scope, r.apiClient)
 	if err != nil {
-		return err
+		return r.ReconcileFailed(ctx, dataLogger, outcomes, err)
 	}
 
-	return nil
+	return r.UpdateStatus(ctx, scope, outcomes)
 }
 
-func (r *Reconciler) Finalize(ctx context.Context, dataLogger *appv1.DataLogger, req ctrl.Request) error {
+// ReconcileFailed marks the dataLogger as not ready with the outcomes of the sub-reconcilers
+// and returns the passed reconcile error
+func (r *Reconciler) ReconcileFailed(ctx context.Context, dataLogger *appv1.DataLogger, outcomes []registry.Outcome, err error) error {
 	logger := log.FromContext(ctx)
 
-	ns := &corev1.Namespace{}
-
-	err := r.GetResource(ctx, ns, dataLogger.Spec.CustomName, req.Namespace, logger)
-	if err != nil {
-		return err
+	statusErr := r.patchStatus(ctx, dataLogger, func() {
+		SetComponents(dataLogger, outcomes)
+		setCondition(dataLogger, appv1.ConditionReady, metav1.ConditionFalse, ReasonReconcileFailed, err.Error())
+	})
+	if statusErr != nil {
+		logger.Error(statusErr, "unable to update dataLogger status", "name", dataLogger.Name, "namespace", dataLogger.Namespace)
 	}
 
-	err = r.DeleteResource(ctx, ns, logger)
-	if err != nil {
-		return err
+	return err
+}
+
+// Finalize cleans up the children of the dataLogger as its deletion policy says and removes the finalizer.
+// It can be called again at any step, children that are already gone are skipped.
+func (r *Reconciler) Finalize(ctx context.Context, dataLogger *appv1.DataLogger) error {
+	logger := log.FromContext(ctx)
+
+	switch DeletionPolicy(dataLogger) {
+	case appv1.DeletionPolicyRetain:
+		if err := r.RetainChildren(ctx, dataLogger); err != nil {
+			return err
+		}
+	case appv1.DeletionPolicyOrphan:
+		if err := r.OrphanChildren(ctx, dataLogger); err != nil {
+			return err
+		}
+	default:
+		if err := r.DeleteChildren(ctx, dataLogger); err != nil {
+			return err
+		}
 	}
 
-	if err = r.apiClient.Update(ctx, dataLogger); err != nil {
+	err := r.patchFinalizers(ctx, dataLogger, func() { controllerutil.RemoveFinalizer(dataLogger, ClusterFinalizer) })
+	if err != nil {
 		logger.Error(
 			err,
 			"unable to update dataLogger CR instance",
-			dataLogger.Spec.CustomName,
-			req.Namespace,
+			"name", dataLogger.Name,
+			"namespace", dataLogger.Namespace,
 		)
 
+		r.recorder.Eventf(dataLogger, corev1.EventTypeWarning, pkg.EventReasonFinalizeBlocked,
+			"Unable to remove the finalizer: %v", err)
+
 		return err
 	}
 
-	logger.Info("Resource was updated successfully", dataLogger.Spec.CustomName, dataLogger.GetNamespace())
+	logger.Info("Finalizer was removed", "name", dataLogger.Name, "namespace", dataLogger.Namespace)
 
 	return nil
 }
 
+// patchFinalizers patches the finalizers of the dataLogger after they were changed by mutate. The patch fails
+// with a conflict if the finalizers were changed by someone else in the meantime, instead of dropping theirs.
+func (r *Reconciler) patchFinalizers(ctx context.Context, dataLogger *appv1.DataLogger, mutate func()) error {
+	patch := client.MergeFromWithOptions(dataLogger.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	mutate()
+
+	return r.apiClient.Patch(ctx, dataLogger, patch)
+}
+
+// patchStatus patches the status of the dataLogger after it was changed by mutate. Only the changed
+// fields are sent, so that the write does not fail on a dataLogger that was changed in the meantime.
+func (r *Reconciler) patchStatus(ctx context.Context, dataLogger *appv1.DataLogger, mutate func()) error {
+	patch := client.MergeFrom(dataLogger.DeepCopy())
+
+	mutate()
+
+	return r.apiClient.Status().Patch(ctx, dataLogger, patch)
+}
+
 func (r *Reconciler) DeleteResource(
 	ctx context.Context,
 	obj client.Object,
 	logger pkg.LogOperator,
+	opts ...client.DeleteOption,
 ) error {
-	err := r.apiClient.Delete(ctx, obj)
+	err := r.apiClient.Delete(ctx, obj, opts...)
 	if err != nil {
 		logger.Error(
 			err,
 			"unable to delete dataLogger CD instance",
-			obj.GetName(), obj.GetNamespace(),
-			obj.GetNamespace(),
+			"name", obj.GetName(),
+			"namespace", obj.GetNamespace(),
 		)
 
 		return err
 	}
 
-	logger.Info("Resource was deleted successfully", obj.GetName(), obj.GetNamespace())
-
-	return nil
-}
-
-func (r *Reconciler) GetResource(
-	ctx context.Context,
-	obj client.Object,
-	name string,
-	namespace string,
-	logger pkg.LogOperator,
-) error {
-	err := r.apiClient.Get(ctx, client.ObjectKey{Name: namespace}, obj)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			logger.Error(
-				err,
-				"unable to fetch dataLogger CR instance",
-				name,
-				namespace,
-			)
-		}
-
-		return err
-	}
+	logger.Info("Resource was deleted successfully", "name", obj.GetName(), "namespace", obj.GetNamespace())
 
 	return nil
 }
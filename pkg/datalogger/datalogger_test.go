@@ -2,6 +2,7 @@ package datalogger
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -9,14 +10,25 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	appv1 "stackit.cloud/datalogger/api/v1"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/metrics"
+	"stackit.cloud/datalogger/pkg/registry"
+	"stackit.cloud/datalogger/pkg/requeue"
 )
 
 func TestDataLoggerReconcilerFinalizerWithNoErrors(t *testing.T) {
@@ -25,10 +37,12 @@ func TestDataLoggerReconcilerFinalizerWithNoErrors(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
 	mockedApiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+	mockedStatus := pkg.NewMockStatusWriterOperator(mockCtrl)
 
 	mockedDeployment := pkg.NewMockDeploymentOperator(mockCtrl)
 	mockedService := pkg.NewMockServiceOperator(mockCtrl)
-	reconciler := NewReconciler(mockedApiClient, mockedDeployment, mockedService)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
+	reconciler := NewReconciler(mockedApiClient, newSteps(t, mockedDeployment, mockedService), mockedDrift, record.NewFakeRecorder(10), DefaultFinalizeTimeout)
 
 	tests := []struct {
 		name        string
@@ -50,7 +64,7 @@ func TestDataLoggerReconcilerFinalizerWithNoErrors(t *testing.T) {
 			want:        ctrl.Result{Requeue: false},
 			crdObject: &appv1.DataLogger{
 				ObjectMeta: metav1.ObjectMeta{
-					DeletionTimestamp: nil, Finalizers: []string{},
+					DeletionTimestamp: nil, Finalizers: []string{ClusterFinalizer},
 				},
 			},
 		},
@@ -64,8 +78,11 @@ func TestDataLoggerReconcilerFinalizerWithNoErrors(t *testing.T) {
 			want:        ctrl.Result{Requeue: false},
 			crdObject: &appv1.DataLogger{
 				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "my-namespace2",
 					DeletionTimestamp: &metav1.Time{Time: time.Now().Add(10 * time.Second)}, Finalizers: []string{ClusterFinalizer},
+					UID: "datalogger-uid",
 				},
+				Spec: appv1.DataLoggerSpec{CustomName: "datalogger-finalized"},
 			},
 		},
 	}
@@ -80,18 +97,24 @@ func TestDataLoggerReconcilerFinalizerWithNoErrors(t *testing.T) {
 			reqType := types.NamespacedName{Namespace: test.namespace, Name: test.namespace}
 			req := reconcile.Request{NamespacedName: reqType}
 
+			mockedApiClient.EXPECT().Status().Times(test.times).Return(mockedStatus)
+			mockedStatus.EXPECT().Patch(ctx, test.crdObject, gomock.Any()).Times(test.times).Return(nil)
+
 			if test.crdObject.DeletionTimestamp != nil {
-				mockedApiClient.EXPECT().Get(
-					ctx, client.ObjectKey{Name: test.namespace}, ns,
-				).Times(test.times).Return(test.errorValue1)
+				expectChildrenGone(ctx, mockedApiClient, test.crdObject, test.times)
 
-				mockedApiClient.EXPECT().Delete(ctx, ns).Times(test.times).Return(test.errorValue2)
+				// The namespace is terminating, which confirms its deletion
+				mockedApiClient.EXPECT().Get(
+					ctx, client.ObjectKey{Name: test.namespace}, gomock.AssignableToTypeOf(ns),
+				).Times(test.times).Do(terminatingNamespace(test.crdObject)).Return(test.errorValue1)
 
-				mockedApiClient.EXPECT().Update(ctx, test.crdObject).Times(test.times).Return(test.errorValue3)
+				mockedApiClient.EXPECT().Patch(ctx, test.crdObject, gomock.Any()).Times(test.times).Return(test.errorValue3)
 			} else {
 
-				mockedDeployment.EXPECT().Reconcile(ctx, req, mockedApiClient).Times(test.times).Return(test.errorValue1)
-				mockedService.EXPECT().Reconcile(ctx, req, mockedApiClient).Times(test.times).Return(test.errorValue1)
+				mockedDeployment.EXPECT().Reconcile(ctx, pkg.NewScope(test.crdObject), mockedApiClient).Times(test.times).Return(test.errorValue1)
+				mockedService.EXPECT().Reconcile(ctx, pkg.NewScope(test.crdObject), mockedApiClient).Times(test.times).Return(test.errorValue1)
+
+				mockedDrift.EXPECT().Pop(client.ObjectKey{}).Times(test.times).Return(nil, metav1.Time{}, false)
 			}
 
 			err := reconciler.Reconcile(ctx, req, test.crdObject)
@@ -106,10 +129,11 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
 	mockedApiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+	mockedStatus := pkg.NewMockStatusWriterOperator(mockCtrl)
 
 	mockedDeployment := pkg.NewMockDeploymentOperator(mockCtrl)
 	mockedService := pkg.NewMockServiceOperator(mockCtrl)
-	reconciler := NewReconciler(mockedApiClient, mockedDeployment, mockedService)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
 
 	tests := []struct {
 		name        string
@@ -120,6 +144,7 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 		times       int
 		want        ctrl.Result
 		crdObject   *appv1.DataLogger
+		event       string
 	}{
 		{
 			name:        "DataLoggerController0",
@@ -131,7 +156,7 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 			want:        ctrl.Result{Requeue: false},
 			crdObject: &appv1.DataLogger{
 				ObjectMeta: metav1.ObjectMeta{
-					DeletionTimestamp: nil, Finalizers: []string{},
+					DeletionTimestamp: nil, Finalizers: []string{ClusterFinalizer},
 				},
 			},
 		},
@@ -145,7 +170,7 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 			want:        ctrl.Result{Requeue: false},
 			crdObject: &appv1.DataLogger{
 				ObjectMeta: metav1.ObjectMeta{
-					DeletionTimestamp: nil, Finalizers: []string{},
+					DeletionTimestamp: nil, Finalizers: []string{ClusterFinalizer},
 				},
 			},
 		},
@@ -159,9 +184,13 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 			want:        ctrl.Result{Requeue: false},
 			crdObject: &appv1.DataLogger{
 				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "my-namespace2",
 					DeletionTimestamp: &metav1.Time{Time: time.Now().Add(10 * time.Second)}, Finalizers: []string{ClusterFinalizer},
+					UID: "datalogger-uid",
 				},
+				Spec: appv1.DataLoggerSpec{CustomName: "datalogger-finalized"},
 			},
+			event: "Warning FinalizeBlocked Unable to fetch Namespace my-namespace2: get method api error 1",
 		},
 		{
 			name:        "DataLoggerController3",
@@ -173,9 +202,13 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 			want:        ctrl.Result{Requeue: false},
 			crdObject: &appv1.DataLogger{
 				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "my-namespace3",
 					DeletionTimestamp: &metav1.Time{Time: time.Now().Add(10 * time.Second)}, Finalizers: []string{ClusterFinalizer},
+					UID: "datalogger-uid",
 				},
+				Spec: appv1.DataLoggerSpec{CustomName: "datalogger-finalized"},
 			},
+			event: "Warning DeleteFailed Unable to delete Namespace my-namespace3: get method api error 3",
 		},
 		{
 			name:        "DataLoggerController4",
@@ -187,9 +220,13 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 			want:        ctrl.Result{Requeue: false},
 			crdObject: &appv1.DataLogger{
 				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "my-namespace4",
 					DeletionTimestamp: &metav1.Time{Time: time.Now().Add(10 * time.Second)}, Finalizers: []string{ClusterFinalizer},
+					UID: "datalogger-uid",
 				},
+				Spec: appv1.DataLoggerSpec{CustomName: "datalogger-finalized"},
 			},
+			event: "Warning FinalizeBlocked Unable to remove the finalizer: get method api error 4",
 		},
 	}
 
@@ -198,28 +235,36 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
 			t.Parallel()
 
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewReconciler(mockedApiClient, newSteps(t, mockedDeployment, mockedService), mockedDrift, recorder, DefaultFinalizeTimeout)
+
 			ns := &corev1.Namespace{}
 
 			reqType := types.NamespacedName{Namespace: test.namespace, Name: test.namespace}
 			req := reconcile.Request{NamespacedName: reqType}
 
+			mockedApiClient.EXPECT().Status().Times(test.times).Return(mockedStatus)
+			mockedStatus.EXPECT().Patch(ctx, test.crdObject, gomock.Any()).Times(test.times).Return(nil)
+
 			if test.crdObject.DeletionTimestamp != nil {
+				expectChildrenGone(ctx, mockedApiClient, test.crdObject, test.times)
+
 				if test.errorValue1 != nil && (test.errorValue2 == nil && test.errorValue3 == nil) {
 					mockedApiClient.EXPECT().Get(
-						ctx, client.ObjectKey{Name: test.namespace}, ns,
+						ctx, client.ObjectKey{Name: test.namespace}, gomock.AssignableToTypeOf(ns),
 					).Times(test.times).Return(test.errorValue1)
 
 					err := reconciler.Reconcile(ctx, req, test.crdObject)
 					require.EqualValues(t, err.Error(), test.errorValue1.Error())
 				}
 
-				if test.errorValue2 != nil && (test.errorValue1 == nil && test.errorValue2 == nil) {
+				if test.errorValue2 != nil && (test.errorValue1 == nil && test.errorValue3 == nil) {
 					mockedApiClient.EXPECT().Get(
-						ctx, client.ObjectKey{Name: test.namespace}, ns,
-					).Times(test.times).Return(test.errorValue1)
+						ctx, client.ObjectKey{Name: test.namespace}, gomock.AssignableToTypeOf(ns),
+					).Times(test.times).Do(ownNamespace(test.crdObject)).Return(test.errorValue1)
 
 					mockedApiClient.EXPECT().Delete(
-						ctx, ns,
+						ctx, ownedNamespace(test.crdObject),
 					).Times(test.times).Return(test.errorValue2)
 
 					err := reconciler.Reconcile(ctx, req, test.crdObject)
@@ -228,15 +273,11 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 
 				if test.errorValue3 != nil && (test.errorValue2 == nil && test.errorValue1 == nil) {
 					mockedApiClient.EXPECT().Get(
-						ctx, client.ObjectKey{Name: test.namespace}, ns,
-					).Times(test.times).Return(test.errorValue1)
+						ctx, client.ObjectKey{Name: test.namespace}, gomock.AssignableToTypeOf(ns),
+					).Times(test.times).Do(terminatingNamespace(test.crdObject)).Return(test.errorValue1)
 
-					mockedApiClient.EXPECT().Delete(
-						ctx, ns,
-					).Times(test.times).Return(test.errorValue2)
-
-					mockedApiClient.EXPECT().Update(
-						ctx, test.crdObject,
+					mockedApiClient.EXPECT().Patch(
+						ctx, test.crdObject, gomock.Any(),
 					).Times(test.times).Return(test.errorValue3)
 
 					err := reconciler.Reconcile(ctx, req, test.crdObject)
@@ -244,21 +285,745 @@ func TestDataLoggerReconcilerFinalizerWithErrors(t *testing.T) {
 				}
 			} else {
 				if test.errorValue1 != nil {
-					mockedDeployment.EXPECT().Reconcile(ctx, req, mockedApiClient).Times(test.times).Return(test.errorValue1)
+					mockedDeployment.EXPECT().Reconcile(ctx, pkg.NewScope(test.crdObject), mockedApiClient).Times(test.times).Return(test.errorValue1)
 
 					err := reconciler.Reconcile(ctx, req, test.crdObject)
 					require.EqualValues(t, err.Error(), test.errorValue1.Error())
 				}
 
 				if test.errorValue2 != nil {
-					mockedDeployment.EXPECT().Reconcile(ctx, req, mockedApiClient).Times(test.times).Return(test.errorValue1)
-					mockedService.EXPECT().Reconcile(ctx, req, mockedApiClient).Times(1).Return(test.errorValue2)
+					mockedDeployment.EXPECT().Reconcile(ctx, pkg.NewScope(test.crdObject), mockedApiClient).Times(test.times).Return(test.errorValue1)
+					mockedService.EXPECT().Reconcile(ctx, pkg.NewScope(test.crdObject), mockedApiClient).Times(1).Return(test.errorValue2)
 
 					err := reconciler.Reconcile(ctx, req, test.crdObject)
 
 					require.EqualValues(t, err.Error(), test.errorValue2.Error())
 				}
 			}
+
+			if test.event != "" {
+				require.EqualValues(t, test.event, <-recorder.Events)
+			}
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
+
+// newSteps registers the deployment and service operators as main does
+func newSteps(t *testing.T, deployment, service pkg.ReconcileOperator) *registry.Registry {
+	steps := registry.NewRegistry()
+	require.Nil(t, steps.Register(metrics.ReconcilerDeployment, deployment))
+	require.Nil(t, steps.Register(metrics.ReconcilerService, service, metrics.ReconcilerDeployment))
+
+	return steps
+}
+
+// ownedNamespace returns the namespace of the dataLogger labeled as owned by it
+func ownedNamespace(dataLogger *appv1.DataLogger) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: dataLogger.Namespace, Labels: map[string]string{OwnerUIDLabel: string(dataLogger.UID)},
+		},
+	}
+}
+
+// ownNamespace fills the namespace returned by a mocked Get with the owner label of the dataLogger
+func ownNamespace(dataLogger *appv1.DataLogger) func(context.Context, client.ObjectKey, client.Object, ...client.GetOption) {
+	return func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) {
+		obj.SetLabels(ownedNamespace(dataLogger).Labels)
+	}
+}
+
+// terminatingNamespace fills the namespace returned by a mocked Get like ownNamespace and marks it as terminating
+func terminatingNamespace(dataLogger *appv1.DataLogger) func(context.Context, client.ObjectKey, client.Object, ...client.GetOption) {
+	return func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) {
+		ownNamespace(dataLogger)(ctx, key, obj, opts...)
+		obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	}
+}
+
+// expectChildrenGone expects the lookup of the namespaced children of a deleted dataLogger, which are already gone
+func expectChildrenGone(ctx context.Context, apiClient *pkg.MockAPIClientOperator, dataLogger *appv1.DataLogger, times int) {
+	key := client.ObjectKey{Name: dataLogger.Spec.CustomName, Namespace: dataLogger.Namespace}
+
+	apiClient.EXPECT().Get(
+		ctx, key, gomock.AssignableToTypeOf(&corev1.Service{}),
+	).Times(times).Return(apierrors.NewNotFound(schema.GroupResource{Resource: "services"}, key.Name))
+	apiClient.EXPECT().Get(
+		ctx, key, gomock.AssignableToTypeOf(&appsv1.Deployment{}),
+	).Times(times).Return(apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, key.Name))
+	apiClient.EXPECT().Get(
+		ctx, key, gomock.AssignableToTypeOf(&corev1.PersistentVolumeClaim{}),
+	).Times(times).Return(apierrors.NewNotFound(schema.GroupResource{Resource: "persistentvolumeclaims"}, key.Name))
+}
+
+func TestDataLoggerFinalizeDeletionPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		policy  appv1.DeletionPolicy
+		service bool
+		err     bool
+		event   string
+	}{
+		{
+			name:    "delete-waiting",
+			policy:  appv1.DeletionPolicyDelete,
+			service: true,
+			err:     true,
+			event:   "",
+		},
+		{
+			name:    "retain",
+			policy:  appv1.DeletionPolicyRetain,
+			service: true,
+			err:     false,
+			event:   "Normal Retained Retained Service datalogger-42",
+		},
+		{
+			name:    "orphan",
+			policy:  appv1.DeletionPolicyOrphan,
+			service: true,
+			err:     false,
+			event:   "Normal Orphaned Orphaned Service datalogger-42",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+
+			mockedApiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+			mockedStatus := pkg.NewMockStatusWriterOperator(mockCtrl)
+
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewReconciler(
+				mockedApiClient,
+				newSteps(t, pkg.NewMockDeploymentOperator(mockCtrl), pkg.NewMockServiceOperator(mockCtrl)),
+				pkg.NewMockDriftOperator(mockCtrl),
+				recorder,
+				DefaultFinalizeTimeout,
+			)
+
+			dataLogger := &appv1.DataLogger{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "datalogger-sample", Namespace: "my-namespace1", UID: "datalogger-uid",
+					DeletionTimestamp: &metav1.Time{Time: time.Now()}, Finalizers: []string{ClusterFinalizer},
+				},
+				Spec: appv1.DataLoggerSpec{CustomName: "datalogger-42", DeletionPolicy: test.policy},
+			}
+
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dataLogger)}
+			key := client.ObjectKey{Name: "datalogger-42", Namespace: "my-namespace1"}
+
+			other := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "logging", UID: "other-uid"}
+			owners := []metav1.OwnerReference{
+				{APIVersion: appv1.GroupVersion.String(), Kind: "DataLogger", Name: "datalogger-sample", UID: "datalogger-uid"},
+				other,
+			}
+
+			mockedApiClient.EXPECT().Status().AnyTimes().Return(mockedStatus)
+			mockedStatus.EXPECT().Patch(ctx, dataLogger, gomock.Any()).MinTimes(1).Return(nil)
+
+			mockedApiClient.EXPECT().Get(
+				ctx, key, gomock.AssignableToTypeOf(&corev1.Service{}),
+			).Times(1).DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				obj.SetOwnerReferences(owners)
+				return nil
+			})
+
+			switch test.policy {
+			case appv1.DeletionPolicyDelete:
+				service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+					Name: key.Name, Namespace: key.Namespace, OwnerReferences: owners,
+				}}
+
+				mockedApiClient.EXPECT().Delete(
+					ctx, service, client.PropagationPolicy(metav1.DeletePropagationForeground),
+				).Times(1).Return(nil)
+			case appv1.DeletionPolicyRetain, appv1.DeletionPolicyOrphan:
+				service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+					Name: key.Name, Namespace: key.Namespace, OwnerReferences: []metav1.OwnerReference{other},
+				}}
+
+				mockedApiClient.EXPECT().Patch(ctx, service, gomock.Any()).Times(1).Return(nil)
+				mockedApiClient.EXPECT().Get(
+					ctx, key, gomock.AssignableToTypeOf(&appsv1.Deployment{}),
+				).Times(1).Return(apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, key.Name))
+				mockedApiClient.EXPECT().Get(
+					ctx, key, gomock.AssignableToTypeOf(&corev1.PersistentVolumeClaim{}),
+				).Times(1).Return(apierrors.NewNotFound(schema.GroupResource{Resource: "persistentvolumeclaims"}, key.Name))
+			}
+
+			if !test.err {
+				mockedApiClient.EXPECT().Patch(ctx, dataLogger, gomock.Any()).Times(1).Return(nil)
+			}
+
+			err := reconciler.Reconcile(ctx, req, dataLogger)
+			if test.err {
+				require.Error(t, err)
+				require.EqualValues(t, requeue.ClassWaiting, requeue.ClassOf(err))
+				require.EqualValues(t, []string{ClusterFinalizer}, dataLogger.ObjectMeta.Finalizers)
+
+				condition := meta.FindStatusCondition(dataLogger.Status.Conditions, appv1.ConditionFinalizing)
+				require.EqualValues(t, ReasonWaitingForDeletion, condition.Reason)
+				require.EqualValues(t, "waiting for Service datalogger-42 to be deleted", condition.Message)
+			} else {
+				require.Nil(t, err)
+				require.Empty(t, dataLogger.ObjectMeta.Finalizers)
+			}
+
+			if test.event != "" {
+				require.EqualValues(t, test.event, <-recorder.Events)
+			}
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func TestDataLoggerFinalizeOrphanKeepsChildren(t *testing.T) {
+	ctx := context.Background()
+
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "datalogger-sample", Namespace: "my-namespace1", UID: "datalogger-uid",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()}, Finalizers: []string{ClusterFinalizer},
+		},
+		Spec: appv1.DataLoggerSpec{CustomName: "datalogger-42", DeletionPolicy: appv1.DeletionPolicyOrphan},
+	}
+
+	other := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "logging", UID: "other-uid"}
+	owners := []metav1.OwnerReference{*metav1.NewControllerRef(dataLogger, appv1.GroupVersion.WithKind("DataLogger")), other}
+
+	children := Children(dataLogger)
+	objs := []client.Object{dataLogger}
+	for _, child := range children {
+		// The namespace is not owned through owner references
+		if child.GetNamespace() != "" {
+			child.SetOwnerReferences(owners)
+		}
+		objs = append(objs, child.DeepCopyObject().(client.Object))
+	}
+
+	scheme := runtime.NewScheme()
+	require.Nil(t, clientgoscheme.AddToScheme(scheme))
+	require.Nil(t, appv1.AddToScheme(scheme))
+
+	apiClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&appv1.DataLogger{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewReconciler(apiClient, registry.NewRegistry(), nil, recorder, DefaultFinalizeTimeout)
+
+	require.Nil(t, reconciler.Finalize(ctx, dataLogger))
+
+	for _, child := range children {
+		require.Nil(t, apiClient.Get(ctx, client.ObjectKeyFromObject(child), child))
+
+		if child.GetNamespace() != "" {
+			require.EqualValues(t, []metav1.OwnerReference{other}, child.GetOwnerReferences())
+		}
+	}
+
+	require.EqualValues(t, "Normal Orphaned Orphaned Service datalogger-42", <-recorder.Events)
+	require.EqualValues(t, "Normal Orphaned Orphaned Deployment datalogger-42", <-recorder.Events)
+	require.EqualValues(t, "Normal Orphaned Orphaned PersistentVolumeClaim datalogger-42", <-recorder.Events)
+	require.Empty(t, recorder.Events)
+}
+
+func TestNamespaceDeletionRefusal(t *testing.T) {
+	dataLogger := &appv1.DataLogger{ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample", UID: "datalogger-uid"}}
+
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		refusal     string
+	}{
+		{
+			name:    "owned",
+			labels:  map[string]string{OwnerUIDLabel: "datalogger-uid"},
+			refusal: "",
+		},
+		{
+			name:    "unlabeled",
+			refusal: "it is not labeled with app.stackit.cloud/owner-uid=datalogger-uid",
+		},
+		{
+			name:    "foreign-owner",
+			labels:  map[string]string{OwnerUIDLabel: "other-uid"},
+			refusal: "it is not labeled with app.stackit.cloud/owner-uid=datalogger-uid",
+		},
+		{
+			name:        "protected",
+			labels:      map[string]string{OwnerUIDLabel: "datalogger-uid"},
+			annotations: map[string]string{ProtectAnnotation: "true"},
+			refusal:     "it is protected by the stackit.cloud/protect annotation",
+		},
+		{
+			name:        "protection-disabled",
+			labels:      map[string]string{OwnerUIDLabel: "datalogger-uid"},
+			annotations: map[string]string{ProtectAnnotation: "false"},
+			refusal:     "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: "my-namespace1", Labels: test.labels, Annotations: test.annotations,
+			}}
+
+			require.EqualValues(t, test.refusal, NamespaceDeletionRefusal(ns, dataLogger))
+		})
+	}
+}
+
+func TestDataLoggerFinalizeKeepsForeignNamespace(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	mockedApiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+	mockedStatus := pkg.NewMockStatusWriterOperator(mockCtrl)
+
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewReconciler(
+		mockedApiClient,
+		newSteps(t, pkg.NewMockDeploymentOperator(mockCtrl), pkg.NewMockServiceOperator(mockCtrl)),
+		pkg.NewMockDriftOperator(mockCtrl),
+		recorder,
+		DefaultFinalizeTimeout,
+	)
+
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "datalogger-sample", Namespace: "my-namespace1", UID: "datalogger-uid",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()}, Finalizers: []string{ClusterFinalizer},
+		},
+		Spec: appv1.DataLoggerSpec{CustomName: "datalogger-42", DeletionPolicy: appv1.DeletionPolicyDelete},
+	}
+
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dataLogger)}
+
+	mockedApiClient.EXPECT().Status().AnyTimes().Return(mockedStatus)
+	mockedStatus.EXPECT().Patch(ctx, dataLogger, gomock.Any()).MinTimes(1).Return(nil)
+	expectChildrenGone(ctx, mockedApiClient, dataLogger, 1)
+
+	// The tenant namespace was not created by the operator
+	mockedApiClient.EXPECT().Get(
+		ctx, client.ObjectKey{Name: "my-namespace1"}, gomock.AssignableToTypeOf(&corev1.Namespace{}),
+	).Times(1).DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+		obj.SetName(key.Name)
+		return nil
+	})
+	mockedApiClient.EXPECT().Patch(ctx, dataLogger, gomock.Any()).Times(1).Return(nil)
+
+	err := reconciler.Reconcile(ctx, req, dataLogger)
+	require.Nil(t, err)
+	require.Empty(t, dataLogger.ObjectMeta.Finalizers)
+
+	require.EqualValues(t,
+		"Warning DeletionRefused Namespace my-namespace1 is kept: it is not labeled with app.stackit.cloud/owner-uid=datalogger-uid",
+		<-recorder.Events,
+	)
+	require.Empty(t, recorder.Events)
+}
+
+func TestDataLoggerReconcileAddsFinalizer(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		patchErr error
+	}{
+		{
+			name:     "added",
+			patchErr: nil,
+		},
+		{
+			name:     "conflict",
+			patchErr: apierrors.NewConflict(schema.GroupResource{Group: "app.stackit.cloud", Resource: "dataloggers"}, "datalogger-sample", errors.New("changed")),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+
+			mockedApiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+			mockedStatus := pkg.NewMockStatusWriterOperator(mockCtrl)
+			mockedDeployment := pkg.NewMockDeploymentOperator(mockCtrl)
+
+			reconciler := NewReconciler(
+				mockedApiClient,
+				newSteps(t, mockedDeployment, pkg.NewMockServiceOperator(mockCtrl)),
+				pkg.NewMockDriftOperator(mockCtrl),
+				record.NewFakeRecorder(10),
+				DefaultFinalizeTimeout,
+			)
+
+			dataLogger := &appv1.DataLogger{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "datalogger-sample", Namespace: "my-namespace1", ResourceVersion: "7",
+					Finalizers: []string{"other.io/finalizer"},
+				},
+			}
+
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dataLogger)}
+			deploymentErr := errors.New("deployment failed")
+
+			mockedApiClient.EXPECT().Patch(ctx, dataLogger, gomock.Any()).Times(1).DoAndReturn(
+				func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					data, err := patch.Data(obj)
+					require.Nil(t, err)
+					require.JSONEq(t,
+						`{"metadata":{"finalizers":["other.io/finalizer","finalizer.stackit.cloud/datalogger"],"resourceVersion":"7"}}`,
+						string(data),
+					)
+
+					return test.patchErr
+				})
+
+			if test.patchErr == nil {
+				mockedDeployment.EXPECT().Reconcile(ctx, pkg.NewScope(dataLogger), mockedApiClient).Times(1).Return(deploymentErr)
+				mockedApiClient.EXPECT().Status().Times(1).Return(mockedStatus)
+				mockedStatus.EXPECT().Patch(ctx, dataLogger, gomock.Any()).Times(1).Return(nil)
+			}
+
+			err := reconciler.Reconcile(ctx, req, dataLogger)
+			if test.patchErr != nil {
+				require.EqualValues(t, test.patchErr, err)
+			} else {
+				require.EqualValues(t, deploymentErr, err)
+			}
+
+			require.EqualValues(t, []string{"other.io/finalizer", ClusterFinalizer}, dataLogger.ObjectMeta.Finalizers)
+		})
+	}
+}
+
+func TestDataLoggerReconcilePatchesStatus(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+
+	mockedApiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+	mockedStatus := pkg.NewMockStatusWriterOperator(mockCtrl)
+	mockedDeployment := pkg.NewMockDeploymentOperator(mockCtrl)
+
+	reconciler := NewReconciler(
+		mockedApiClient,
+		newSteps(t, mockedDeployment, pkg.NewMockServiceOperator(mockCtrl)),
+		pkg.NewMockDriftOperator(mockCtrl),
+		record.NewFakeRecorder(10),
+		DefaultFinalizeTimeout,
+	)
+
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "datalogger-sample", Namespace: "my-namespace1", ResourceVersion: "7",
+			Finalizers: []string{ClusterFinalizer},
+		},
+	}
+	dataLogger.Spec.CustomName = "datalogger-sample"
+
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dataLogger)}
+
+	mockedDeployment.EXPECT().Reconcile(ctx, pkg.NewScope(dataLogger), mockedApiClient).Times(1).Return(errors.New("deployment failed"))
+	mockedApiClient.EXPECT().Status().Times(1).Return(mockedStatus)
+	mockedStatus.EXPECT().Patch(ctx, dataLogger, gomock.Any()).Times(1).DoAndReturn(
+		func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			require.EqualValues(t, types.MergePatchType, patch.Type())
+
+			data, err := patch.Data(obj)
+			require.Nil(t, err)
+
+			// Only the changed status is sent, without a resource version to conflict on
+			changed := map[string]map[string]any{}
+			require.Nil(t, json.Unmarshal(data, &changed))
+			require.Len(t, changed, 1)
+			require.Contains(t, changed["status"], "conditions")
+			require.Contains(t, changed["status"], "components")
+
+			return nil
+		})
+
+	err := reconciler.Reconcile(ctx, req, dataLogger)
+	require.EqualError(t, err, "deployment failed")
+}
+
+func TestDataLoggerReconcileStatusFromScope(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+
+	mockedApiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+	mockedStatus := pkg.NewMockStatusWriterOperator(mockCtrl)
+	mockedDeployment := pkg.NewMockDeploymentOperator(mockCtrl)
+	mockedService := pkg.NewMockServiceOperator(mockCtrl)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
+
+	reconciler := NewReconciler(
+		mockedApiClient, newSteps(t, mockedDeployment, mockedService), mockedDrift, record.NewFakeRecorder(10), DefaultFinalizeTimeout,
+	)
+
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample", Namespace: "my-namespace1", Finalizers: []string{ClusterFinalizer}},
+		Spec:       appv1.DataLoggerSpec{CustomName: "datalogger-42"},
+	}
+
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dataLogger)}
+	objectMeta := metav1.ObjectMeta{Name: "datalogger-42", Namespace: "my-namespace1"}
+
+	// The sub-reconcilers store the children as applied, the status is built from them without fetching them again
+	mockedDeployment.EXPECT().Reconcile(ctx, gomock.Any(), mockedApiClient).Times(1).DoAndReturn(
+		func(ctx context.Context, scope *pkg.Scope, apiClient pkg.APIClientOperator) error {
+			scope.SetChild("Deployment", &appsv1.Deployment{
+				ObjectMeta: *objectMeta.DeepCopy(),
+				Status: appsv1.DeploymentStatus{
+					ReadyReplicas: 1,
+					Conditions:    []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}},
+				},
+			})
+
+			return nil
+		})
+	mockedService.EXPECT().Reconcile(ctx, gomock.Any(), mockedApiClient).Times(1).DoAndReturn(
+		func(ctx context.Context, scope *pkg.Scope, apiClient pkg.APIClientOperator) error {
+			scope.SetChild("Service", &corev1.Service{
+				ObjectMeta: *objectMeta.DeepCopy(),
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 8080}}},
+			})
+
+			return nil
+		})
+
+	mockedDrift.EXPECT().Pop(client.ObjectKeyFromObject(dataLogger)).Times(1).Return(nil, metav1.Time{}, false)
+	mockedApiClient.EXPECT().Status().Times(1).Return(mockedStatus)
+	mockedStatus.EXPECT().Patch(ctx, dataLogger, gomock.Any()).Times(1).Return(nil)
+
+	require.Nil(t, reconciler.Reconcile(ctx, req, dataLogger))
+	require.True(t, meta.IsStatusConditionTrue(dataLogger.Status.Conditions, appv1.ConditionReady))
+	require.EqualValues(t, 1, dataLogger.Status.ReadyReplicas)
+	require.EqualValues(t, "datalogger-42.my-namespace1.svc:8080", dataLogger.Status.Endpoint)
+}
+
+func TestDataLoggerFinalizeSteps(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		gone     = "gone"
+		owned    = "owned"
+		deleting = "deleting"
+		foreign  = "foreign"
+	)
+
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample", Namespace: "my-namespace1"},
+		Spec:       appv1.DataLoggerSpec{CustomName: "datalogger-42"},
+	}
+
+	order := []string{}
+	for _, child := range Children(dataLogger) {
+		order = append(order, fmt.Sprintf("%s %s", kindOf(child), child.GetName()))
+	}
+
+	require.EqualValues(t, []string{
+		"Service datalogger-42", "Deployment datalogger-42", "PersistentVolumeClaim datalogger-42", "Namespace my-namespace1",
+	}, order)
+
+	tests := []struct {
+		name     string
+		age      time.Duration
+		children []string
+		deleted  string
+		waiting  string
+		stuck    bool
+		event    string
+	}{
+		{
+			name:     "delete-service",
+			children: []string{owned},
+			deleted:  "Service",
+			waiting:  "waiting for Service datalogger-42 to be deleted",
+		},
+		{
+			name:     "wait-for-deployment",
+			children: []string{gone, deleting},
+			waiting:  "waiting for Deployment datalogger-42 to be deleted",
+		},
+		{
+			name:     "delete-deployment",
+			children: []string{gone, owned},
+			deleted:  "Deployment",
+			waiting:  "waiting for Deployment datalogger-42 to be deleted",
+		},
+		{
+			name:     "keep-foreign-deployment",
+			children: []string{gone, foreign, gone, gone},
+		},
+		{
+			name:     "delete-pvc",
+			children: []string{gone, gone, owned},
+			deleted:  "PersistentVolumeClaim",
+			waiting:  "waiting for PersistentVolumeClaim datalogger-42 to be deleted",
+		},
+		{
+			name:     "keep-foreign-pvc",
+			children: []string{gone, gone, foreign, gone},
+		},
+		{
+			name:     "delete-namespace",
+			children: []string{gone, gone, gone, owned},
+			deleted:  "Namespace",
+			waiting:  "waiting for Namespace my-namespace1 to be deleted",
+		},
+		{
+			name:     "namespace-terminating",
+			children: []string{gone, gone, gone, deleting},
+		},
+		{
+			name:     "keep-foreign-namespace",
+			children: []string{gone, gone, gone, foreign},
+			event:    "Warning DeletionRefused Namespace my-namespace1 is kept: it is not labeled with app.stackit.cloud/owner-uid=datalogger-uid",
+		},
+		{
+			name:     "stuck",
+			age:      time.Hour,
+			children: []string{gone, deleting},
+			waiting:  "waiting for Deployment datalogger-42 to be deleted",
+			stuck:    true,
+			event:    "Warning FinalizeStuck Finalizing is waiting for Deployment datalogger-42 to be deleted for more than 5m0s",
+		},
+		{
+			name:     "stuck-namespace",
+			age:      time.Hour,
+			children: []string{gone, gone, gone, owned},
+			deleted:  "Namespace",
+			waiting:  "waiting for Namespace my-namespace1 to be deleted",
+			stuck:    true,
+			event:    "Warning FinalizeStuck Finalizing is waiting for Namespace my-namespace1 to be deleted for more than 5m0s",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+
+			mockedApiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+			mockedStatus := pkg.NewMockStatusWriterOperator(mockCtrl)
+
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewReconciler(
+				mockedApiClient,
+				newSteps(t, pkg.NewMockDeploymentOperator(mockCtrl), pkg.NewMockServiceOperator(mockCtrl)),
+				pkg.NewMockDriftOperator(mockCtrl),
+				recorder,
+				DefaultFinalizeTimeout,
+			)
+
+			dataLogger := &appv1.DataLogger{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "datalogger-sample", Namespace: "my-namespace1", UID: "datalogger-uid",
+					DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-test.age)},
+					Finalizers:        []string{"other.io/finalizer", ClusterFinalizer},
+				},
+				Spec: appv1.DataLoggerSpec{CustomName: "datalogger-42"},
+			}
+
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dataLogger)}
+			owners := []metav1.OwnerReference{
+				{APIVersion: appv1.GroupVersion.String(), Kind: "DataLogger", Name: "datalogger-sample", UID: "datalogger-uid"},
+			}
+
+			mockedApiClient.EXPECT().Status().AnyTimes().Return(mockedStatus)
+			mockedStatus.EXPECT().Patch(ctx, dataLogger, gomock.Any()).MinTimes(1).Return(nil)
+
+			// The children are looked up and deleted in their order, until the first one that is waited for
+			calls := []*gomock.Call{}
+
+			for i, child := range Children(dataLogger) {
+				state := test.children[i]
+				_, isNamespace := child.(*corev1.Namespace)
+
+				calls = append(calls, mockedApiClient.EXPECT().Get(
+					ctx, client.ObjectKeyFromObject(child), gomock.AssignableToTypeOf(child),
+				).Times(1).DoAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if state == gone {
+						return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+					}
+
+					if state == deleting {
+						obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+					}
+
+					switch {
+					case state == foreign:
+					case isNamespace:
+						obj.SetLabels(map[string]string{OwnerUIDLabel: "datalogger-uid"})
+					default:
+						obj.SetOwnerReferences(owners)
+					}
+
+					return nil
+				}))
+
+				if kindOf(child) == test.deleted {
+					opts := []any{}
+					if !isNamespace {
+						opts = append(opts, client.PropagationPolicy(metav1.DeletePropagationForeground))
+					}
+
+					calls = append(calls, mockedApiClient.EXPECT().Delete(
+						ctx, gomock.AssignableToTypeOf(child), opts...,
+					).Times(1).Return(nil))
+				}
+
+				if state == owned || (state == deleting && !isNamespace) {
+					break
+				}
+			}
+
+			if test.waiting == "" {
+				calls = append(calls, mockedApiClient.EXPECT().Patch(ctx, dataLogger, gomock.Any()).Times(1).Return(nil))
+			}
+
+			gomock.InOrder(calls...)
+
+			err := reconciler.Reconcile(ctx, req, dataLogger)
+			if test.waiting != "" {
+				require.EqualValues(t, requeue.ClassWaiting, requeue.ClassOf(err))
+				require.EqualValues(t, []string{"other.io/finalizer", ClusterFinalizer}, dataLogger.ObjectMeta.Finalizers)
+
+				condition := meta.FindStatusCondition(dataLogger.Status.Conditions, appv1.ConditionFinalizing)
+				require.EqualValues(t, test.waiting, condition.Message)
+			} else {
+				require.Nil(t, err)
+				require.EqualValues(t, []string{"other.io/finalizer"}, dataLogger.ObjectMeta.Finalizers)
+			}
+
+			require.EqualValues(t, test.stuck, meta.IsStatusConditionTrue(dataLogger.Status.Conditions, appv1.ConditionFinalizeStuck))
+
+			if test.event != "" {
+				require.EqualValues(t, test.event, <-recorder.Events)
+			}
+			require.Empty(t, recorder.Events)
 		})
 	}
 }
@@ -0,0 +1,271 @@
+package datalogger
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/requeue"
+)
+
+// Reasons of the Finalizing condition for the deletion policies
+const (
+	ReasonRetaining          = "Retaining"
+	ReasonOrphaning          = "Orphaning"
+	ReasonWaitingForDeletion = "WaitingForDeletion"
+	ReasonTimedOut           = "TimedOut"
+)
+
+// OwnerUIDLabel marks a namespace as owned by the dataLogger with the UID in the value.
+// The finalizer only deletes namespaces carrying it.
+const OwnerUIDLabel = "app.stackit.cloud/owner-uid"
+
+// ProtectAnnotation keeps a namespace from being deleted by the finalizer, when set to true
+const ProtectAnnotation = "stackit.cloud/protect"
+
+// DeletionPolicy returns the deletion policy of the dataLogger, dataLoggers that were
+// not defaulted by the webhook are deleted as before
+func DeletionPolicy(dataLogger *appv1.DataLogger) appv1.DeletionPolicy {
+	if dataLogger.Spec.DeletionPolicy != "" {
+		return dataLogger.Spec.DeletionPolicy
+	}
+
+	return appv1.DeletionPolicyDelete
+}
+
+// finalizingCondition returns the reason and message of the Finalizing condition for the deletion policy
+func finalizingCondition(policy appv1.DeletionPolicy) (string, string) {
+	switch policy {
+	case appv1.DeletionPolicyRetain:
+		return ReasonRetaining, "removing the owner references of the children"
+	case appv1.DeletionPolicyOrphan:
+		return ReasonOrphaning, "removing the owner references of the children, without deleting anything"
+	default:
+		return ReasonDeleting, "deleting resources"
+	}
+}
+
+// Children returns the Service, the Deployment, the PersistentVolumeClaim of the logger storage and the
+// namespace of the dataLogger, in the order they are deleted
+func Children(dataLogger *appv1.DataLogger) []client.Object {
+	meta := metav1.ObjectMeta{Name: dataLogger.Spec.CustomName, Namespace: dataLogger.Namespace}
+
+	return []client.Object{
+		&corev1.Service{ObjectMeta: *meta.DeepCopy()},
+		&appsv1.Deployment{ObjectMeta: *meta.DeepCopy()},
+		&corev1.PersistentVolumeClaim{ObjectMeta: *meta.DeepCopy()},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: dataLogger.Namespace}},
+	}
+}
+
+// DeleteChildren deletes the children of the dataLogger one after another, the namespaced ones in the foreground.
+// It returns a waiting error until each of them is confirmed gone, children the dataLogger may not delete are kept.
+// The namespace cannot be gone before the dataLogger inside it, so it is confirmed once it is terminating.
+func (r *Reconciler) DeleteChildren(ctx context.Context, dataLogger *appv1.DataLogger) error {
+	logger := log.FromContext(ctx)
+
+	for _, child := range Children(dataLogger) {
+		kind := kindOf(child)
+		_, isNamespace := child.(*corev1.Namespace)
+
+		err := r.apiClient.Get(ctx, client.ObjectKeyFromObject(child), child)
+		if client.IgnoreNotFound(err) != nil {
+			r.recorder.Eventf(dataLogger, corev1.EventTypeWarning, pkg.EventReasonFinalizeBlocked,
+				"Unable to fetch %s %s: %v", kind, child.GetName(), err)
+
+			return err
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if isNamespace && !child.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		if refusal := deletionRefusal(child, dataLogger); refusal != "" {
+			logger.Info("Resource is kept", "kind", kind, "name", child.GetName(), "namespace", child.GetNamespace(), "reason", refusal)
+
+			// A namespace may hold other workloads, keeping it is reported
+			if isNamespace {
+				r.recorder.Eventf(dataLogger, corev1.EventTypeWarning, pkg.EventReasonDeletionRefused,
+					"Namespace %s is kept: %s", child.GetName(), refusal)
+			}
+
+			continue
+		}
+
+		if child.GetDeletionTimestamp().IsZero() {
+			var opts []client.DeleteOption
+			if !isNamespace {
+				opts = append(opts, client.PropagationPolicy(metav1.DeletePropagationForeground))
+			}
+
+			err = r.DeleteResource(ctx, child, logger, opts...)
+			if client.IgnoreNotFound(err) != nil {
+				r.recorder.Eventf(dataLogger, corev1.EventTypeWarning, pkg.EventReasonDeleteFailed,
+					"Unable to delete %s %s: %v", kind, child.GetName(), err)
+
+				return err
+			}
+		}
+
+		return r.waitForDeletion(ctx, dataLogger, fmt.Sprintf("%s %s", kind, child.GetName()))
+	}
+
+	return nil
+}
+
+// waitForDeletion reports the child the dataLogger waits for in the Finalizing condition, or in the
+// FinalizeStuck condition once the finalize timeout passed, and returns a waiting error
+func (r *Reconciler) waitForDeletion(ctx context.Context, dataLogger *appv1.DataLogger, child string) error {
+	message := fmt.Sprintf("waiting for %s to be deleted", child)
+
+	err := r.patchStatus(ctx, dataLogger, func() {
+		setCondition(dataLogger, appv1.ConditionFinalizing, metav1.ConditionTrue, ReasonWaitingForDeletion, message)
+
+		if time.Since(dataLogger.DeletionTimestamp.Time) > r.finalizeTimeout {
+			stuck := fmt.Sprintf("%s for more than %s", message, r.finalizeTimeout)
+
+			if !meta.IsStatusConditionTrue(dataLogger.Status.Conditions, appv1.ConditionFinalizeStuck) {
+				r.recorder.Eventf(dataLogger, corev1.EventTypeWarning, pkg.EventReasonFinalizeStuck, "Finalizing is %s", stuck)
+			}
+
+			setCondition(dataLogger, appv1.ConditionFinalizeStuck, metav1.ConditionTrue, ReasonTimedOut, stuck)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return requeue.Waiting(fmt.Errorf("%s", message))
+}
+
+// deletionRefusal returns why the child must not be deleted together with the dataLogger,
+// or an empty string if it may be deleted
+func deletionRefusal(child client.Object, dataLogger *appv1.DataLogger) string {
+	if ns, ok := child.(*corev1.Namespace); ok {
+		return NamespaceDeletionRefusal(ns, dataLogger)
+	}
+
+	if !isOwnedBy(child, dataLogger) {
+		return "it is not owned by the dataLogger"
+	}
+
+	return ""
+}
+
+// isOwnedBy returns whether the child has an owner reference to the dataLogger
+func isOwnedBy(child client.Object, dataLogger *appv1.DataLogger) bool {
+	for _, reference := range child.GetOwnerReferences() {
+		if reference.UID == dataLogger.UID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RetainChildren removes the owner references to the dataLogger from its children,
+// so that they are kept when the dataLogger is gone
+func (r *Reconciler) RetainChildren(ctx context.Context, dataLogger *appv1.DataLogger) error {
+	return r.releaseChildren(ctx, dataLogger, pkg.EventReasonRetained)
+}
+
+// OrphanChildren removes the owner references to the dataLogger from its children like RetainChildren,
+// as the garbage collector would otherwise delete them together with the dataLogger
+func (r *Reconciler) OrphanChildren(ctx context.Context, dataLogger *appv1.DataLogger) error {
+	return r.releaseChildren(ctx, dataLogger, pkg.EventReasonOrphaned)
+}
+
+// releaseChildren removes the owner references to the dataLogger from its children
+// and records an event with the reason for each released child
+func (r *Reconciler) releaseChildren(ctx context.Context, dataLogger *appv1.DataLogger, reason string) error {
+	logger := log.FromContext(ctx)
+
+	for _, child := range Children(dataLogger) {
+		// The namespace has no owner reference to the dataLogger, it is only deleted by the Delete policy
+		if _, ok := child.(*corev1.Namespace); ok {
+			continue
+		}
+
+		kind := kindOf(child)
+
+		err := r.apiClient.Get(ctx, client.ObjectKeyFromObject(child), child)
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+
+		if err != nil {
+			continue
+		}
+
+		references := child.GetOwnerReferences()
+		kept := make([]metav1.OwnerReference, 0, len(references))
+
+		for _, reference := range references {
+			if reference.UID != dataLogger.UID {
+				kept = append(kept, reference)
+			}
+		}
+
+		if len(kept) == len(references) {
+			continue
+		}
+
+		patch := client.MergeFromWithOptions(child.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+		child.SetOwnerReferences(kept)
+
+		if err = r.apiClient.Patch(ctx, child, patch); err != nil {
+			logger.Error(err, "unable to remove the owner reference", "kind", kind, "name", child.GetName(), "namespace", child.GetNamespace())
+			return err
+		}
+
+		logger.Info("Resource is released", "kind", kind, "name", child.GetName(), "namespace", child.GetNamespace(), "reason", reason)
+
+		r.recorder.Eventf(dataLogger, corev1.EventTypeNormal, reason, "%s %s %s", reason, kind, child.GetName())
+	}
+
+	return nil
+}
+
+// NamespaceDeletionRefusal returns why the namespace must not be deleted together with the
+// dataLogger, or an empty string if it may be deleted
+func NamespaceDeletionRefusal(ns *corev1.Namespace, dataLogger *appv1.DataLogger) string {
+	if protected, _ := strconv.ParseBool(ns.GetAnnotations()[ProtectAnnotation]); protected {
+		return fmt.Sprintf("it is protected by the %s annotation", ProtectAnnotation)
+	}
+
+	owner, ok := ns.GetLabels()[OwnerUIDLabel]
+	if !ok || dataLogger.UID == "" || owner != string(dataLogger.UID) {
+		return fmt.Sprintf("it is not labeled with %s=%s", OwnerUIDLabel, dataLogger.UID)
+	}
+
+	return ""
+}
+
+// kindOf returns the kind of a child of the dataLogger
+func kindOf(child client.Object) string {
+	switch child.(type) {
+	case *corev1.Service:
+		return "Service"
+	case *appsv1.Deployment:
+		return "Deployment"
+	case *corev1.PersistentVolumeClaim:
+		return "PersistentVolumeClaim"
+	case *corev1.Namespace:
+		return "Namespace"
+	default:
+		return fmt.Sprintf("%T", child)
+	}
+}
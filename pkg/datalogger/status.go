@@ -0,0 +1,217 @@
+package datalogger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/metrics"
+	"stackit.cloud/datalogger/pkg/registry"
+)
+
+// Reasons used for the conditions of the dataLogger status
+const (
+	ReasonAvailable       = "Available"
+	ReasonUnavailable     = "Unavailable"
+	ReasonNotFound        = "NotFound"
+	ReasonReconcileFailed = "ReconcileFailed"
+	ReasonDeleting        = "Deleting"
+	ReasonDrifted         = "Drifted"
+	ReasonInSync          = "InSync"
+)
+
+// UpdateStatus writes the state of the children the sub-reconcilers applied in the scope together
+// with their outcomes into the status subresource. The children are not fetched again.
+func (r *Reconciler) UpdateStatus(ctx context.Context, scope *pkg.Scope, outcomes []registry.Outcome) error {
+	logger := log.FromContext(ctx)
+
+	dataLogger := scope.DataLogger
+
+	var deployment *appsv1.Deployment
+	if child, ok := scope.Child("Deployment"); ok {
+		deployment, _ = child.(*appsv1.Deployment)
+	}
+
+	var service *corev1.Service
+	if child, ok := scope.Child("Service"); ok {
+		service, _ = child.(*corev1.Service)
+	}
+
+	// Only the first time the dataLogger turns ready is observed, not when it recovers later on. A dataLogger
+	// that was ready before FirstReadyTime was recorded only gets the marker.
+	turnsReady := dataLogger.Status.FirstReadyTime == nil &&
+		!meta.IsStatusConditionTrue(dataLogger.Status.Conditions, appv1.ConditionReady)
+
+	paths, driftTime, drifted := r.drift.Pop(client.ObjectKeyFromObject(dataLogger))
+
+	err := r.patchStatus(ctx, dataLogger, func() {
+		SetComponents(dataLogger, outcomes)
+		SetStatus(dataLogger, deployment, service)
+		SetDrift(dataLogger, paths, driftTime, drifted)
+	})
+	if err != nil {
+		logger.Error(err, "unable to update dataLogger status", "name", dataLogger.Name, "namespace", dataLogger.Namespace)
+		return err
+	}
+
+	if turnsReady && dataLogger.Status.FirstReadyTime != nil {
+		metrics.TimeToReady.Observe(dataLogger.Status.FirstReadyTime.Sub(dataLogger.CreationTimestamp.Time).Seconds())
+	}
+
+	return nil
+}
+
+// SetComponents records the outcomes of the sub-reconcilers in the status
+func SetComponents(dataLogger *appv1.DataLogger, outcomes []registry.Outcome) {
+	components := make([]appv1.ComponentStatus, 0, len(outcomes))
+
+	for _, outcome := range outcomes {
+		component := appv1.ComponentStatus{Name: outcome.Name, Phase: appv1.ComponentSucceeded}
+
+		switch {
+		case outcome.BlockedBy != "":
+			component.Phase = appv1.ComponentBlocked
+			component.Message = fmt.Sprintf("%s failed", outcome.BlockedBy)
+		case outcome.Err != nil:
+			component.Phase = appv1.ComponentFailed
+			component.Message = outcome.Err.Error()
+		}
+
+		components = append(components, component)
+	}
+
+	dataLogger.Status.Components = components
+}
+
+// Phase returns the phase of the dataLogger, as reported in the metrics of the operator
+func Phase(dataLogger *appv1.DataLogger) string {
+	if !dataLogger.ObjectMeta.DeletionTimestamp.IsZero() {
+		return metrics.PhaseFinalizing
+	}
+
+	ready := meta.FindStatusCondition(dataLogger.Status.Conditions, appv1.ConditionReady)
+
+	switch {
+	case ready == nil:
+		return metrics.PhasePending
+	case ready.Status == metav1.ConditionTrue:
+		return metrics.PhaseReady
+	case ready.Reason == ReasonReconcileFailed:
+		return metrics.PhaseFailed
+	default:
+		return metrics.PhasePending
+	}
+}
+
+// SetStatus computes the status of the dataLogger from the observed deployment and service.
+// A nil deployment or service means that the child does not exist (yet).
+func SetStatus(dataLogger *appv1.DataLogger, deployment *appsv1.Deployment, service *corev1.Service) {
+	status := &dataLogger.Status
+	generation := dataLogger.Generation
+
+	status.ObservedGeneration = generation
+	status.Replicas = 0
+	status.ReadyReplicas = 0
+	status.Endpoint = ""
+
+	deploymentAvailable := false
+
+	if deployment == nil {
+		setCondition(dataLogger, appv1.ConditionDeploymentAvailable, metav1.ConditionFalse,
+			ReasonNotFound, "deployment does not exist")
+	} else {
+		status.Replicas = dataLogger.Spec.Replicas
+		if deployment.Spec.Replicas != nil {
+			status.Replicas = *deployment.Spec.Replicas
+		}
+
+		status.ReadyReplicas = deployment.Status.ReadyReplicas
+
+		deploymentAvailable = isDeploymentAvailable(deployment)
+		if deploymentAvailable {
+			setCondition(dataLogger, appv1.ConditionDeploymentAvailable, metav1.ConditionTrue,
+				ReasonAvailable, fmt.Sprintf("%d/%d replicas ready", status.ReadyReplicas, status.Replicas))
+		} else {
+			setCondition(dataLogger, appv1.ConditionDeploymentAvailable, metav1.ConditionFalse,
+				ReasonUnavailable, fmt.Sprintf("%d/%d replicas ready", status.ReadyReplicas, status.Replicas))
+		}
+	}
+
+	if service == nil {
+		setCondition(dataLogger, appv1.ConditionServiceReady, metav1.ConditionFalse,
+			ReasonNotFound, "service does not exist")
+	} else {
+		status.Endpoint = Endpoint(service)
+
+		setCondition(dataLogger, appv1.ConditionServiceReady, metav1.ConditionTrue,
+			ReasonAvailable, fmt.Sprintf("service is reachable at %s", status.Endpoint))
+	}
+
+	if deploymentAvailable && service != nil {
+		setCondition(dataLogger, appv1.ConditionReady, metav1.ConditionTrue,
+			ReasonAvailable, "all resources are available")
+
+		if status.FirstReadyTime == nil {
+			firstReadyTime := meta.FindStatusCondition(status.Conditions, appv1.ConditionReady).LastTransitionTime
+			status.FirstReadyTime = &firstReadyTime
+		}
+	} else {
+		setCondition(dataLogger, appv1.ConditionReady, metav1.ConditionFalse,
+			ReasonUnavailable, "waiting for resources to become available")
+	}
+}
+
+// SetDrift records in the status whether the children drifted during the last reconcile.
+// The drifted paths are prefixed with the kind of the child, e.g. deployment/spec/replicas.
+func SetDrift(dataLogger *appv1.DataLogger, paths []string, driftTime metav1.Time, drifted bool) {
+	if !drifted {
+		setCondition(dataLogger, appv1.ConditionDrifted, metav1.ConditionFalse,
+			ReasonInSync, "resources match the desired state")
+
+		return
+	}
+
+	dataLogger.Status.LastDriftTime = &driftTime
+
+	setCondition(dataLogger, appv1.ConditionDrifted, metav1.ConditionTrue,
+		ReasonDrifted, fmt.Sprintf("reverted changes made by hand: %s", strings.Join(paths, ", ")))
+}
+
+// Endpoint returns the in-cluster address of the service, e.g. name.namespace.svc:8080
+func Endpoint(service *corev1.Service) string {
+	endpoint := fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+
+	if len(service.Spec.Ports) > 0 {
+		endpoint = fmt.Sprintf("%s:%d", endpoint, service.Spec.Ports[0].Port)
+	}
+
+	return endpoint
+}
+
+func isDeploymentAvailable(deployment *appsv1.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func setCondition(dataLogger *appv1.DataLogger, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&dataLogger.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: dataLogger.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,294 @@
+package datalogger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/metrics"
+	"stackit.cloud/datalogger/pkg/registry"
+)
+
+func TestDataLoggerSetStatus(t *testing.T) {
+	replicas := int32(2)
+
+	availableDeployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: 2,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	unavailableDeployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-42", Namespace: "my-namespace1"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 8080}}},
+	}
+
+	tests := []struct {
+		name          string
+		deployment    *appsv1.Deployment
+		service       *corev1.Service
+		ready         metav1.ConditionStatus
+		available     metav1.ConditionStatus
+		serviceReady  metav1.ConditionStatus
+		readyReplicas int32
+		endpoint      string
+	}{
+		{
+			name:          "ready",
+			deployment:    availableDeployment,
+			service:       service,
+			ready:         metav1.ConditionTrue,
+			available:     metav1.ConditionTrue,
+			serviceReady:  metav1.ConditionTrue,
+			readyReplicas: 2,
+			endpoint:      "datalogger-42.my-namespace1.svc:8080",
+		},
+		{
+			name:          "deployment-unavailable",
+			deployment:    unavailableDeployment,
+			service:       service,
+			ready:         metav1.ConditionFalse,
+			available:     metav1.ConditionFalse,
+			serviceReady:  metav1.ConditionTrue,
+			readyReplicas: 1,
+			endpoint:      "datalogger-42.my-namespace1.svc:8080",
+		},
+		{
+			name:          "children-missing",
+			deployment:    nil,
+			service:       nil,
+			ready:         metav1.ConditionFalse,
+			available:     metav1.ConditionFalse,
+			serviceReady:  metav1.ConditionFalse,
+			readyReplicas: 0,
+			endpoint:      "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			dataLogger := &appv1.DataLogger{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+
+			SetStatus(dataLogger, test.deployment, test.service)
+
+			require.EqualValues(t, 3, dataLogger.Status.ObservedGeneration)
+			require.EqualValues(t, test.readyReplicas, dataLogger.Status.ReadyReplicas)
+			require.EqualValues(t, test.endpoint, dataLogger.Status.Endpoint)
+
+			conditions := dataLogger.Status.Conditions
+			require.EqualValues(t, test.ready, meta.FindStatusCondition(conditions, appv1.ConditionReady).Status)
+			require.EqualValues(t, test.available, meta.FindStatusCondition(conditions, appv1.ConditionDeploymentAvailable).Status)
+			require.EqualValues(t, test.serviceReady, meta.FindStatusCondition(conditions, appv1.ConditionServiceReady).Status)
+			require.EqualValues(t, test.ready == metav1.ConditionTrue, dataLogger.Status.FirstReadyTime != nil)
+		})
+	}
+}
+
+func TestDataLoggerUpdateStatusObservesFirstReady(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+
+	drift := pkg.NewMockDriftOperator(mockCtrl)
+	drift.EXPECT().Pop(gomock.Any()).AnyTimes().Return(nil, metav1.Time{}, false)
+
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "datalogger-sample", Namespace: "my-namespace1",
+			CreationTimestamp: metav1.Time{Time: time.Now().Add(-time.Minute)},
+		},
+		Spec: appv1.DataLoggerSpec{CustomName: "datalogger-42"},
+	}
+
+	objectMeta := metav1.ObjectMeta{Name: "datalogger-42", Namespace: "my-namespace1"}
+	deployment := &appsv1.Deployment{ObjectMeta: *objectMeta.DeepCopy()}
+	service := &corev1.Service{ObjectMeta: *objectMeta.DeepCopy()}
+
+	scheme := runtime.NewScheme()
+	require.Nil(t, clientgoscheme.AddToScheme(scheme))
+	require.Nil(t, appv1.AddToScheme(scheme))
+
+	apiClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(dataLogger).
+		WithStatusSubresource(&appv1.DataLogger{}).
+		Build()
+
+	reconciler := NewReconciler(apiClient, registry.NewRegistry(), drift, record.NewFakeRecorder(10), DefaultFinalizeTimeout)
+
+	scope := pkg.NewScope(dataLogger)
+	scope.SetChild("Service", service)
+
+	observed := histogramCount(t)
+
+	// The dataLogger turns ready, becomes unavailable and recovers
+	for _, available := range []corev1.ConditionStatus{corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionTrue} {
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: available}}
+		scope.SetChild("Deployment", deployment.DeepCopy())
+
+		require.Nil(t, reconciler.UpdateStatus(ctx, scope, nil))
+
+		ready := meta.FindStatusCondition(dataLogger.Status.Conditions, appv1.ConditionReady)
+		require.EqualValues(t, metav1.ConditionStatus(available), ready.Status)
+		require.NotNil(t, dataLogger.Status.FirstReadyTime)
+	}
+
+	require.EqualValues(t, observed+1, histogramCount(t))
+}
+
+// histogramCount returns the number of observations of the time-to-ready histogram
+func histogramCount(t *testing.T) uint64 {
+	metric := &dto.Metric{}
+	require.Nil(t, metrics.TimeToReady.Write(metric))
+
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestDataLoggerSetDrift(t *testing.T) {
+	driftTime := metav1.Now()
+
+	tests := []struct {
+		name          string
+		paths         []string
+		drifted       bool
+		status        metav1.ConditionStatus
+		lastDriftTime *metav1.Time
+	}{
+		{
+			name:          "in-sync",
+			paths:         nil,
+			drifted:       false,
+			status:        metav1.ConditionFalse,
+			lastDriftTime: nil,
+		},
+		{
+			name:          "drifted",
+			paths:         []string{"deployment/spec/replicas", "service/spec/type"},
+			drifted:       true,
+			status:        metav1.ConditionTrue,
+			lastDriftTime: &driftTime,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			dataLogger := &appv1.DataLogger{}
+
+			SetDrift(dataLogger, test.paths, driftTime, test.drifted)
+
+			require.EqualValues(t, test.lastDriftTime, dataLogger.Status.LastDriftTime)
+
+			condition := meta.FindStatusCondition(dataLogger.Status.Conditions, appv1.ConditionDrifted)
+			require.EqualValues(t, test.status, condition.Status)
+
+			for _, path := range test.paths {
+				require.Contains(t, condition.Message, path)
+			}
+		})
+	}
+}
+
+func TestDataLoggerPhase(t *testing.T) {
+	tests := []struct {
+		name      string
+		deleted   bool
+		condition *metav1.Condition
+		phase     string
+	}{
+		{
+			name:  "new",
+			phase: metrics.PhasePending,
+		},
+		{
+			name:      "ready",
+			condition: &metav1.Condition{Status: metav1.ConditionTrue, Reason: ReasonAvailable},
+			phase:     metrics.PhaseReady,
+		},
+		{
+			name:      "unavailable",
+			condition: &metav1.Condition{Status: metav1.ConditionFalse, Reason: ReasonUnavailable},
+			phase:     metrics.PhasePending,
+		},
+		{
+			name:      "failed",
+			condition: &metav1.Condition{Status: metav1.ConditionFalse, Reason: ReasonReconcileFailed},
+			phase:     metrics.PhaseFailed,
+		},
+		{
+			name:      "finalizing",
+			deleted:   true,
+			condition: &metav1.Condition{Status: metav1.ConditionTrue, Reason: ReasonAvailable},
+			phase:     metrics.PhaseFinalizing,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			dataLogger := &appv1.DataLogger{}
+
+			if test.deleted {
+				dataLogger.ObjectMeta.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+
+			if test.condition != nil {
+				setCondition(dataLogger, appv1.ConditionReady, test.condition.Status, test.condition.Reason, "")
+			}
+
+			require.EqualValues(t, test.phase, Phase(dataLogger))
+		})
+	}
+}
+
+func TestDataLoggerSetComponents(t *testing.T) {
+	dataLogger := &appv1.DataLogger{}
+
+	SetComponents(dataLogger, []registry.Outcome{
+		{Name: "deployment", Err: errors.New("quota exceeded")},
+		{Name: "configmap"},
+		{Name: "service", BlockedBy: "deployment"},
+	})
+
+	require.EqualValues(t, []appv1.ComponentStatus{
+		{Name: "deployment", Phase: appv1.ComponentFailed, Message: "quota exceeded"},
+		{Name: "configmap", Phase: appv1.ComponentSucceeded},
+		{Name: "service", Phase: appv1.ComponentBlocked, Message: "deployment failed"},
+	}, dataLogger.Status.Components)
+}
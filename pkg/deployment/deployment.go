@@ -6,81 +6,123 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	appv1 "stackit.cloud/datalogger/api/v1"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/requeue"
+	"stackit.cloud/datalogger/pkg/utils/apply"
 )
 
 const labelName = "app.kubernetes.io/name"
 const labelInstance = "app.kubernetes.io/instance"
 
+// ContainerName is the name of the logger container inside the pod template
+const ContainerName = "datalogger-container"
+
+// DefaultImage is used when neither the dataLogger nor the operator configure an image
+const DefaultImage = "kennethreitz/httpbin"
+
+// Defaults holds the operator level settings for fields a dataLogger may leave empty
+type Defaults struct {
+	Image           string
+	ImagePullPolicy corev1.PullPolicy
+}
+
 type Deployment struct {
 	reference pkg.DeploymentReferenceController
+	drift     pkg.DriftOperator
+	recorder  pkg.EventRecorderOperator
+	defaults  Defaults
 }
 
-func NewDeployment(reference pkg.DeploymentReferenceController) *Deployment {
-	return &Deployment{reference: reference}
+func NewDeployment(
+	reference pkg.DeploymentReferenceController,
+	drift pkg.DriftOperator,
+	recorder pkg.EventRecorderOperator,
+	defaults Defaults,
+) *Deployment {
+	if defaults.Image == "" {
+		defaults.Image = DefaultImage
+	}
+
+	return &Deployment{reference: reference, drift: drift, recorder: recorder, defaults: defaults}
 }
 
-func (d Deployment) Reconcile(ctx context.Context, req ctrl.Request, r pkg.APIClientOperator) error {
-	dataLogger := &appv1.DataLogger{}
-	if err := r.Get(ctx, req.NamespacedName, dataLogger); err != nil {
-		return err
-	}
+func (d Deployment) Reconcile(ctx context.Context, scope *pkg.Scope, r pkg.APIClientOperator) error {
+	dataLogger := scope.DataLogger
 
 	deployment := d.CreateDeployment(dataLogger)
 
 	// Set owner reference to the dataLogger instance
 	err := d.reference.SetControllerReference(dataLogger, deployment, r.Scheme())
 	if err != nil {
-		return err
+		// The object is controlled by another owner, retrying does not help
+		return requeue.Terminal(err)
 	}
 
-	// Create or update the Deployment
-	err = d.CreateOrUpdate(ctx, deployment, r)
+	// Apply the Deployment
+	live, err := d.Apply(ctx, dataLogger, deployment, r)
 	if err != nil {
 		return err
 	}
 
+	scope.SetChild("Deployment", live)
+
 	return nil
 }
 
-// CreateOrUpdate creates the resource if it doesn't exist, or updates it if it does.
-func (Deployment) CreateOrUpdate(ctx context.Context, obj *appsv1.Deployment, r pkg.APIClientOperator) error {
+// Apply server-side applies the Deployment, unless it is unchanged since the last apply and did not drift,
+// and returns the Deployment as it is in the cluster afterwards. The replicas are left to an autoscaler,
+// if one manages them through the scale subresource.
+func (d Deployment) Apply(
+	ctx context.Context,
+	dataLogger *appv1.DataLogger,
+	obj *appsv1.Deployment,
+	r pkg.APIClientOperator,
+) (*appsv1.Deployment, error) {
 	logger := log.FromContext(ctx)
 
-	err := r.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}, obj)
-	if err != nil {
-		if client.IgnoreNotFound(err) != nil {
-			logger.Error(err, "error getting resource", obj.GetName(), obj.GetNamespace())
-			return err
-		}
+	current := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}, current)
+	if client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "error getting deployment", "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return nil, err
+	}
+
+	var live client.Object
+	if err == nil {
+		live = current
 
-		// Resource does not exist, create it
-		err = r.Create(ctx, obj)
-		if err != nil {
-			logger.Error(err, "error creating resource", obj.GetName(), obj.GetNamespace())
-			return err
+		if apply.ManagedThrough(current, "scale", "spec", "replicas") {
+			obj.Spec.Replicas = nil
 		}
+	}
 
-		logger.Info("Deployment was created successfully.", obj.GetName(), obj.GetNamespace())
+	// Skip the write if the desired state did not change since the last apply
+	written, err := apply.PatchIfChanged(ctx, r, d.drift, dataLogger, obj, live)
+	if err != nil {
+		logger.Error(err, "error applying deployment", "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return nil, err
+	}
 
-		return nil
+	if !written {
+		return current, nil
 	}
 
-	// Resource exists, update it
-	err = r.Update(ctx, obj)
-	if err != nil {
-		logger.Error(err, "error updating resource", obj.GetName(), obj.GetNamespace())
-		return err
+	logger.Info("Deployment was applied successfully.", "name", obj.GetName(), "namespace", obj.GetNamespace())
+
+	if live == nil {
+		d.recorder.Eventf(dataLogger, corev1.EventTypeNormal, pkg.EventReasonCreated, "Created Deployment %s", obj.GetName())
+	} else {
+		d.recorder.Eventf(dataLogger, corev1.EventTypeNormal, pkg.EventReasonUpdated, "Updated Deployment %s", obj.GetName())
 	}
 
-	return nil
+	// The apply filled obj with the response of the API server
+	return obj, nil
 }
 
-func (Deployment) CreateDeployment(dataLogger *appv1.DataLogger) *appsv1.Deployment {
+func (d Deployment) CreateDeployment(dataLogger *appv1.DataLogger) *appsv1.Deployment {
 	labels := map[string]string{
 		labelName:     dataLogger.ObjectMeta.Labels[labelName],
 		labelInstance: dataLogger.ObjectMeta.Labels[labelInstance],
@@ -95,6 +137,10 @@ func (Deployment) CreateDeployment(dataLogger *appv1.DataLogger) *appsv1.Deploym
 
 	// Reconciliation logic: Create or update Deployment
 	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      dataLogger.Spec.CustomName,
 			Namespace: dataLogger.ObjectMeta.Namespace, // Inherit namespace from dataLogger
@@ -109,16 +155,21 @@ func (Deployment) CreateDeployment(dataLogger *appv1.DataLogger) *appsv1.Deploym
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
+					ImagePullSecrets: dataLogger.Spec.ImagePullSecrets,
 					Containers: []corev1.Container{
 						{
-							Name:  "datalogger-container",
-							Image: "kennethreitz/httpbin", // Use the image from dataLogger
-							Env: []corev1.EnvVar{
+							Name:            ContainerName,
+							Image:           d.image(dataLogger),
+							ImagePullPolicy: d.imagePullPolicy(dataLogger),
+							Command:         dataLogger.Spec.Command,
+							Args:            dataLogger.Spec.Args,
+							Env: append([]corev1.EnvVar{
 								{
 									Name:  "CUSTOM_NAME",
 									Value: dataLogger.Spec.CustomName,
 								},
-							},
+							}, dataLogger.Spec.Env...),
+							EnvFrom: dataLogger.Spec.EnvFrom,
 							Ports: []corev1.ContainerPort{
 								{
 									ContainerPort: dataLogger.Spec.Port,
@@ -133,3 +184,21 @@ func (Deployment) CreateDeployment(dataLogger *appv1.DataLogger) *appsv1.Deploym
 
 	return deployment
 }
+
+// image returns the image of the dataLogger or the operator default
+func (d Deployment) image(dataLogger *appv1.DataLogger) string {
+	if dataLogger.Spec.Image != "" {
+		return dataLogger.Spec.Image
+	}
+
+	return d.defaults.Image
+}
+
+// imagePullPolicy returns the pull policy of the dataLogger or the operator default
+func (d Deployment) imagePullPolicy(dataLogger *appv1.DataLogger) corev1.PullPolicy {
+	if dataLogger.Spec.ImagePullPolicy != "" {
+		return dataLogger.Spec.ImagePullPolicy
+	}
+
+	return d.defaults.ImagePullPolicy
+}
@@ -7,16 +7,21 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	appv1 "stackit.cloud/datalogger/api/v1"
+	appv2 "stackit.cloud/datalogger/api/v2"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/metrics"
+	"stackit.cloud/datalogger/pkg/utils/apply"
+	"stackit.cloud/datalogger/pkg/utils/hash"
 )
 
 func TestDeploymentReconcileWithNoErrors(t *testing.T) {
@@ -27,6 +32,7 @@ func TestDeploymentReconcileWithNoErrors(t *testing.T) {
 	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
 
 	mockedReference := pkg.NewMockDeploymentReferenceController(mockCtrl)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
 
 	tests := []struct {
 		name        string
@@ -56,7 +62,8 @@ func TestDeploymentReconcileWithNoErrors(t *testing.T) {
 		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
 			t.Parallel()
 
-			reconciler := NewDeployment(mockedReference)
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewDeployment(mockedReference, mockedDrift, recorder, Defaults{})
 
 			labels := map[string]string{
 				"app.kubernetes.io/name":     "app.kubernetes.io/name",
@@ -64,26 +71,9 @@ func TestDeploymentReconcileWithNoErrors(t *testing.T) {
 				"app":                        test.name,
 			}
 
-			reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-			req := reconcile.Request{NamespacedName: reqType}
-
-			dataLogger := &appv1.DataLogger{}
-			apiClient.EXPECT().Get(
-				ctx,
-				reqType,
-				dataLogger, gomock.Any(),
-			).Times(1).Do(func(ctx context.Context, key client.ObjectKey, obj *appv1.DataLogger, opts ...interface{}) error {
-				obj.ObjectMeta.Namespace = test.namespace
-				obj.ObjectMeta.Name = test.name
-				obj.ObjectMeta.Labels = labels
-				obj.Spec.CustomName = test.name
-
-				return nil
-			}).Return(test.errorValue1)
-
 			apiClient.EXPECT().Scheme().Times(1).Return(test.errorValue1)
 
-			dataLogger = &appv1.DataLogger{}
+			dataLogger := &appv1.DataLogger{}
 
 			dataLogger.ObjectMeta.Labels = labels
 			dataLogger.Spec.CustomName = test.name
@@ -104,15 +94,24 @@ func TestDeploymentReconcileWithNoErrors(t *testing.T) {
 			}
 
 			deployment = reconciler.CreateDeployment(dataLogger)
-			apiClient.EXPECT().Get(ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, deployment).Times(1).Return(test.errorValue2)
+			apiClient.EXPECT().Get(ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, &appsv1.Deployment{}).Times(1).Return(test.errorValue2)
 
 			apiClient.EXPECT().Scheme().Times(1).Return(test.errorValue1)
 			mockedReference.EXPECT().SetControllerReference(dataLogger, deployment, apiClient.Scheme())
 
-			apiClient.EXPECT().Update(ctx, deployment).Times(1).Return(test.errorValue2)
+			apiClient.EXPECT().Patch(
+				ctx, withHash(t, deployment), client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+			).Times(1).Return(test.errorValue2)
+
+			scope := pkg.NewScope(dataLogger)
 
-			err := reconciler.Reconcile(ctx, req, apiClient)
+			err := reconciler.Reconcile(ctx, scope, apiClient)
 			require.Nil(t, err)
+			require.EqualValues(t, "Normal Updated Updated Deployment "+test.name, <-recorder.Events)
+
+			child, ok := scope.Child("Deployment")
+			require.True(t, ok)
+			require.EqualValues(t, deployment.Spec, child.(*appsv1.Deployment).Spec)
 		})
 	}
 }
@@ -125,6 +124,7 @@ func TestDeploymentReconcileWithErrors(t *testing.T) {
 	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
 
 	mockedReference := pkg.NewMockDeploymentReferenceController(mockCtrl)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
 
 	tests := []struct {
 		name        string
@@ -134,13 +134,6 @@ func TestDeploymentReconcileWithErrors(t *testing.T) {
 		times       int
 		notFound    *errors2.StatusError
 	}{
-		{
-			name:        "DataLoggerController-1",
-			namespace:   "my-namespace1",
-			errorValue1: errors.New("get error 1"),
-			errorValue2: nil,
-			times:       1,
-		},
 		{
 			name:        "DataLoggerController-2",
 			namespace:   "my-namespace2",
@@ -170,22 +163,9 @@ func TestDeploymentReconcileWithErrors(t *testing.T) {
 		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
 			t.Parallel()
 
-			if test.errorValue1 != nil && test.errorValue2 == nil && test.notFound == nil {
-				reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-				req := reconcile.Request{NamespacedName: reqType}
-				dataLogger := &appv1.DataLogger{}
-
-				apiClient.EXPECT().Get(ctx, reqType, dataLogger, gomock.Any()).Times(1).Return(test.errorValue1)
-
-				reconciler := NewDeployment(mockedReference)
-
-				err := reconciler.Reconcile(ctx, req, apiClient)
-
-				require.EqualValues(t, err.Error(), "get error 1")
-			}
-
 			if test.errorValue1 == nil && test.errorValue2 != nil && test.notFound == nil {
-				reconciler := NewDeployment(mockedReference)
+				recorder := record.NewFakeRecorder(10)
+				reconciler := NewDeployment(mockedReference, mockedDrift, recorder, Defaults{})
 
 				labels := map[string]string{
 					"app.kubernetes.io/name":     "app.kubernetes.io/name",
@@ -193,26 +173,9 @@ func TestDeploymentReconcileWithErrors(t *testing.T) {
 					"app":                        test.name,
 				}
 
-				reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-				req := reconcile.Request{NamespacedName: reqType}
-
-				dataLogger := &appv1.DataLogger{}
-				apiClient.EXPECT().Get(
-					ctx,
-					reqType,
-					dataLogger, gomock.Any(),
-				).Times(1).Do(func(ctx context.Context, key client.ObjectKey, obj *appv1.DataLogger, opts ...interface{}) error {
-					obj.ObjectMeta.Namespace = test.namespace
-					obj.ObjectMeta.Name = test.name
-					obj.ObjectMeta.Labels = labels
-					obj.Spec.CustomName = test.name
-
-					return nil
-				}).Return(test.errorValue1)
-
 				apiClient.EXPECT().Scheme().Times(1).Return(nil)
 
-				dataLogger = &appv1.DataLogger{}
+				dataLogger := &appv1.DataLogger{}
 
 				dataLogger.ObjectMeta.Labels = labels
 				dataLogger.Spec.CustomName = test.name
@@ -234,18 +197,19 @@ func TestDeploymentReconcileWithErrors(t *testing.T) {
 
 				deployment = reconciler.CreateDeployment(dataLogger)
 
-				apiClient.EXPECT().Get(ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, deployment).Times(1).Return(test.errorValue2)
+				apiClient.EXPECT().Get(ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, &appsv1.Deployment{}).Times(1).Return(test.errorValue2)
 
 				apiClient.EXPECT().Scheme().Times(1).Return(nil)
 
 				mockedReference.EXPECT().SetControllerReference(dataLogger, deployment, apiClient.Scheme()).Times(1).Return(nil)
 
-				err := reconciler.Reconcile(ctx, req, apiClient)
+				err := reconciler.Reconcile(ctx, pkg.NewScope(dataLogger), apiClient)
 				require.EqualValues(t, err.Error(), "get error 2")
 			}
 
 			if test.errorValue1 == nil && test.errorValue2 == nil && test.notFound == nil {
-				reconciler := NewDeployment(mockedReference)
+				recorder := record.NewFakeRecorder(10)
+				reconciler := NewDeployment(mockedReference, mockedDrift, recorder, Defaults{})
 
 				labels := map[string]string{
 					"app.kubernetes.io/name":     "app.kubernetes.io/name",
@@ -253,26 +217,9 @@ func TestDeploymentReconcileWithErrors(t *testing.T) {
 					"app":                        test.name,
 				}
 
-				reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-				req := reconcile.Request{NamespacedName: reqType}
-
-				dataLogger := &appv1.DataLogger{}
-				apiClient.EXPECT().Get(
-					ctx,
-					reqType,
-					dataLogger, gomock.Any(),
-				).Times(1).Do(func(ctx context.Context, key client.ObjectKey, obj *appv1.DataLogger, opts ...interface{}) error {
-					obj.ObjectMeta.Namespace = test.namespace
-					obj.ObjectMeta.Name = test.name
-					obj.ObjectMeta.Labels = labels
-					obj.Spec.CustomName = test.name
-
-					return nil
-				}).Return(test.errorValue1)
-
 				apiClient.EXPECT().Scheme().Times(2).Return(test.errorValue1)
 
-				dataLogger = &appv1.DataLogger{}
+				dataLogger := &appv1.DataLogger{}
 
 				dataLogger.ObjectMeta.Labels = labels
 				dataLogger.Spec.CustomName = test.name
@@ -283,14 +230,15 @@ func TestDeploymentReconcileWithErrors(t *testing.T) {
 
 				mockedReference.EXPECT().SetControllerReference(dataLogger, deployment, apiClient.Scheme()).Times(1).Return(errors.New("owner error"))
 
-				err := reconciler.Reconcile(ctx, req, apiClient)
+				err := reconciler.Reconcile(ctx, pkg.NewScope(dataLogger), apiClient)
 
 				require.EqualValues(t, err.Error(), "owner error")
 			}
 
 			// not found
 			if test.errorValue1 == nil && test.errorValue2 == nil && test.notFound != nil {
-				reconciler := NewDeployment(mockedReference)
+				recorder := record.NewFakeRecorder(10)
+				reconciler := NewDeployment(mockedReference, mockedDrift, recorder, Defaults{})
 
 				labels := map[string]string{
 					"app.kubernetes.io/name":     "app.kubernetes.io/name",
@@ -298,26 +246,9 @@ func TestDeploymentReconcileWithErrors(t *testing.T) {
 					"app":                        test.name,
 				}
 
-				reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-				req := reconcile.Request{NamespacedName: reqType}
-
-				dataLogger := &appv1.DataLogger{}
-				apiClient.EXPECT().Get(
-					ctx,
-					reqType,
-					dataLogger, gomock.Any(),
-				).Times(1).Do(func(ctx context.Context, key client.ObjectKey, obj *appv1.DataLogger, opts ...interface{}) error {
-					obj.ObjectMeta.Namespace = test.namespace
-					obj.ObjectMeta.Name = test.name
-					obj.ObjectMeta.Labels = labels
-					obj.Spec.CustomName = test.name
-
-					return nil
-				}).Return(test.errorValue1)
-
 				apiClient.EXPECT().Scheme().Times(1).Return(nil)
 
-				dataLogger = &appv1.DataLogger{}
+				dataLogger := &appv1.DataLogger{}
 
 				dataLogger.ObjectMeta.Labels = labels
 				dataLogger.Spec.CustomName = test.name
@@ -339,15 +270,287 @@ func TestDeploymentReconcileWithErrors(t *testing.T) {
 
 				deployment = reconciler.CreateDeployment(dataLogger)
 
-				apiClient.EXPECT().Get(ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, deployment).Times(1).Return(test.notFound)
+				apiClient.EXPECT().Get(ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, &appsv1.Deployment{}).Times(1).Return(test.notFound)
 				apiClient.EXPECT().Scheme().Times(1).Return(nil)
 				mockedReference.EXPECT().SetControllerReference(dataLogger, deployment, apiClient.Scheme()).Return(nil)
 
-				apiClient.EXPECT().Create(ctx, deployment).Times(1).Return(nil)
+				apiClient.EXPECT().Patch(
+					ctx, withHash(t, deployment), client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+				).Times(1).Return(nil)
 
-				err := reconciler.Reconcile(ctx, req, apiClient)
+				err := reconciler.Reconcile(ctx, pkg.NewScope(dataLogger), apiClient)
 				require.Nil(t, err)
+				require.EqualValues(t, "Normal Created Created Deployment "+test.name, <-recorder.Events)
 			}
 		})
 	}
 }
+
+func TestCreateDeploymentContainer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockedReference := pkg.NewMockDeploymentReferenceController(mockCtrl)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
+
+	tests := []struct {
+		name       string
+		defaults   Defaults
+		spec       appv1.DataLoggerSpec
+		image      string
+		pullPolicy corev1.PullPolicy
+	}{
+		{
+			name:       "DataLoggerController-1",
+			defaults:   Defaults{},
+			spec:       appv1.DataLoggerSpec{CustomName: "datalogger-1"},
+			image:      DefaultImage,
+			pullPolicy: "",
+		},
+		{
+			name:       "DataLoggerController-2",
+			defaults:   Defaults{Image: "registry.local/logger:1.0", ImagePullPolicy: corev1.PullIfNotPresent},
+			spec:       appv1.DataLoggerSpec{CustomName: "datalogger-2"},
+			image:      "registry.local/logger:1.0",
+			pullPolicy: corev1.PullIfNotPresent,
+		},
+		{
+			name:     "DataLoggerController-3",
+			defaults: Defaults{Image: "registry.local/logger:1.0", ImagePullPolicy: corev1.PullIfNotPresent},
+			spec: appv1.DataLoggerSpec{
+				CustomName:       "datalogger-3",
+				Image:            "registry.local/team-logger:2.0",
+				ImagePullPolicy:  corev1.PullAlways,
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-secret"}},
+				Command:          []string{"/logger"},
+				Args:             []string{"--verbose"},
+				Env:              []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+				EnvFrom: []corev1.EnvFromSource{
+					{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "logger"}}},
+				},
+			},
+			image:      "registry.local/team-logger:2.0",
+			pullPolicy: corev1.PullAlways,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewDeployment(mockedReference, mockedDrift, recorder, test.defaults)
+
+			dataLogger := &appv1.DataLogger{Spec: test.spec}
+
+			deployment := reconciler.CreateDeployment(dataLogger)
+			podSpec := deployment.Spec.Template.Spec
+			container := podSpec.Containers[0]
+
+			require.EqualValues(t, ContainerName, container.Name)
+			require.EqualValues(t, test.image, container.Image)
+			require.EqualValues(t, test.pullPolicy, container.ImagePullPolicy)
+			require.EqualValues(t, test.spec.ImagePullSecrets, podSpec.ImagePullSecrets)
+			require.EqualValues(t, test.spec.Command, container.Command)
+			require.EqualValues(t, test.spec.Args, container.Args)
+			require.EqualValues(t, test.spec.EnvFrom, container.EnvFrom)
+			require.EqualValues(t, corev1.EnvVar{Name: "CUSTOM_NAME", Value: test.spec.CustomName}, container.Env[0])
+			require.Len(t, container.Env, len(test.spec.Env)+1)
+			require.Subset(t, container.Env, test.spec.Env)
+		})
+	}
+}
+
+func TestCreateDeploymentWithDefaults(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockedReference := pkg.NewMockDeploymentReferenceController(mockCtrl)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
+
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewDeployment(mockedReference, mockedDrift, recorder, Defaults{})
+
+	dataLogger := &appv1.DataLogger{Spec: appv1.DataLoggerSpec{CustomName: "datalogger-42"}}
+
+	defaulter := &appv1.DataLoggerDefaulter{}
+	defaulter.SetDefaults(&dataLogger.Spec)
+
+	deployment := reconciler.CreateDeployment(dataLogger)
+
+	require.EqualValues(t, appv2.DefaultReplicas, *deployment.Spec.Replicas)
+	require.EqualValues(t, appv2.DefaultPort, deployment.Spec.Template.Spec.Containers[0].Ports[0].ContainerPort)
+}
+
+func TestDeploymentApplyWithAutoscaler(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+
+	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+
+	mockedReference := pkg.NewMockDeploymentReferenceController(mockCtrl)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
+
+	tests := []struct {
+		name         string
+		managedField metav1.ManagedFieldsEntry
+		keepReplicas bool
+	}{
+		{
+			name: "DataLoggerController-1",
+			managedField: metav1.ManagedFieldsEntry{
+				Manager:     "kube-controller-manager",
+				Subresource: "scale",
+				FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+			},
+			keepReplicas: false,
+		},
+		{
+			name: "DataLoggerController-2",
+			managedField: metav1.ManagedFieldsEntry{
+				Manager:  apply.FieldManager,
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+			},
+			keepReplicas: true,
+		},
+		{
+			name: "DataLoggerController-3",
+			managedField: metav1.ManagedFieldsEntry{
+				Manager:     "kubectl",
+				Subresource: "scale",
+				FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{}}}`)},
+			},
+			keepReplicas: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewDeployment(mockedReference, mockedDrift, recorder, Defaults{})
+
+			dataLogger := &appv1.DataLogger{}
+			dataLogger.ObjectMeta.Namespace = "my-namespace1"
+			dataLogger.Spec.CustomName = test.name
+			dataLogger.Spec.Replicas = 2
+
+			deployment := reconciler.CreateDeployment(dataLogger)
+
+			apiClient.EXPECT().Get(
+				ctx, client.ObjectKey{Name: test.name, Namespace: "my-namespace1"}, &appsv1.Deployment{},
+			).Times(1).Do(func(ctx context.Context, key client.ObjectKey, obj *appsv1.Deployment, opts ...interface{}) error {
+				obj.ObjectMeta.ManagedFields = []metav1.ManagedFieldsEntry{test.managedField}
+
+				return nil
+			}).Return(nil)
+
+			apiClient.EXPECT().Patch(
+				ctx, deployment, client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+			).Times(1).Return(nil)
+
+			_, err := reconciler.Apply(ctx, dataLogger, deployment, apiClient)
+			require.Nil(t, err)
+
+			if test.keepReplicas {
+				require.EqualValues(t, 2, *deployment.Spec.Replicas)
+			} else {
+				require.Nil(t, deployment.Spec.Replicas)
+			}
+		})
+	}
+}
+
+func TestDeploymentApplyUnchanged(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+
+	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+
+	mockedReference := pkg.NewMockDeploymentReferenceController(mockCtrl)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
+
+	tests := []struct {
+		name    string
+		drifted bool
+		result  string
+	}{
+		{
+			name:    "DataLoggerController-1",
+			drifted: false,
+			result:  metrics.ResultSkipped,
+		},
+		{
+			name:    "DataLoggerController-2",
+			drifted: true,
+			result:  metrics.ResultApplied,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewDeployment(mockedReference, mockedDrift, recorder, Defaults{})
+
+			dataLogger := &appv1.DataLogger{}
+			dataLogger.ObjectMeta.Namespace = "my-namespace1"
+			dataLogger.Spec.CustomName = test.name
+
+			applied := withHash(t, reconciler.CreateDeployment(dataLogger))
+
+			current := applied.DeepCopy()
+			current.Spec.Replicas = nil
+			current.Status.ReadyReplicas = 1
+
+			apiClient.EXPECT().Get(
+				ctx, client.ObjectKey{Name: test.name, Namespace: "my-namespace1"}, &appsv1.Deployment{},
+			).Times(1).Do(func(ctx context.Context, key client.ObjectKey, obj *appsv1.Deployment, opts ...interface{}) error {
+				current.DeepCopyInto(obj)
+
+				return nil
+			}).Return(nil)
+
+			mockedDrift.EXPECT().Detect(ctx, dataLogger, applied, current).Times(1).Return(test.drifted, nil)
+
+			if test.drifted {
+				apiClient.EXPECT().Patch(
+					ctx, applied, client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+				).Times(1).Return(nil)
+			}
+
+			writes := testutil.ToFloat64(metrics.Writes.WithLabelValues("Deployment", test.result))
+
+			live, err := reconciler.Apply(ctx, dataLogger, reconciler.CreateDeployment(dataLogger), apiClient)
+			require.Nil(t, err)
+
+			require.EqualValues(t, writes+1, testutil.ToFloat64(metrics.Writes.WithLabelValues("Deployment", test.result)))
+
+			if test.drifted {
+				require.EqualValues(t, applied, live)
+				require.EqualValues(t, "Normal Updated Updated Deployment "+test.name, <-recorder.Events)
+			} else {
+				// The Deployment in the cluster is returned, with its status
+				require.EqualValues(t, current, live)
+				require.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
+
+// withHash returns a copy of the deployment carrying the hash annotation of its desired state
+func withHash(t *testing.T, deployment *appsv1.Deployment) *appsv1.Deployment {
+	t.Helper()
+
+	hashed := deployment.DeepCopy()
+
+	annotations, err := hash.ComputeToAnnotation(hashed)
+	require.Nil(t, err)
+
+	hashed.SetAnnotations(annotations)
+
+	return hashed
+}
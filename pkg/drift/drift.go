@@ -0,0 +1,199 @@
+// Package drift detects changes made by hand to the objects managed by the operator
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/utils/diff"
+)
+
+// ReasonDrifted is the reason of the event emitted on a drift
+const ReasonDrifted = "Drifted"
+
+// maxEventPaths limits the number of drifted paths listed in an event
+const maxEventPaths = 5
+
+// report describes the drift found for the children of a dataLogger
+type report struct {
+	Paths []string
+	Time  metav1.Time
+}
+
+type Detector struct {
+	recorder pkg.EventRecorderOperator
+
+	mu      sync.Mutex
+	reports map[types.NamespacedName]report
+}
+
+func NewDetector(recorder pkg.EventRecorderOperator) *Detector {
+	return &Detector{recorder: recorder, reports: map[types.NamespacedName]report{}}
+}
+
+// Detect compares the live object with the desired state last applied by the operator.
+// Fields the operator does not set (server defaults, status, metadata of others) are ignored.
+// A drift is logged, emitted as a Warning event on the owner and kept until Pop is called.
+func (d *Detector) Detect(ctx context.Context, owner, desired, live client.Object) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	paths, patch, err := Paths(desired, live)
+	if err != nil {
+		return false, err
+	}
+
+	if len(paths) == 0 {
+		return false, nil
+	}
+
+	kind := desired.GetObjectKind().GroupVersionKind().Kind
+
+	logger.Info("drift detected", "kind", kind, "name", live.GetName(), "namespace", live.GetNamespace(),
+		"patch", string(patch))
+
+	d.recorder.Event(owner, corev1.EventTypeWarning, ReasonDrifted,
+		fmt.Sprintf("%s %s drifted from the desired state at %s", kind, live.GetName(), summarize(paths)))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := client.ObjectKeyFromObject(owner)
+	found := d.reports[key]
+	found.Paths = append(found.Paths, prefix(strings.ToLower(kind), paths)...)
+	found.Time = metav1.Now()
+	d.reports[key] = found
+
+	return true, nil
+}
+
+// Pop returns and forgets the drifted paths and the time of the last drift found for
+// the children of the dataLogger since the last call
+func (d *Detector) Pop(key types.NamespacedName) ([]string, metav1.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	found, ok := d.reports[key]
+	delete(d.reports, key)
+
+	return found.Paths, found.Time, ok
+}
+
+// Paths returns the JSON pointers of the fields of live that differ from desired, together
+// with the JSON patch from desired to live
+func Paths(desired, live client.Object) ([]string, []byte, error) {
+	desiredFields, err := normalize(desired)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	liveFields, err := normalize(live)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	liveFields, _ = prune(desiredFields, liveFields).(map[string]any)
+
+	patch, err := diff.JSON(
+		&unstructured.Unstructured{Object: desiredFields},
+		&unstructured.Unstructured{Object: liveFields},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operations := []struct {
+		Path string `json:"path"`
+	}{}
+	if err = json.Unmarshal(patch, &operations); err != nil {
+		return nil, nil, err
+	}
+
+	paths := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		paths = append(paths, operation.Path)
+	}
+
+	return paths, patch, nil
+}
+
+// normalize converts obj into its field map without the fields that are never desired
+func normalize(obj client.Object) (map[string]any, error) {
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(fields, "apiVersion")
+	delete(fields, "kind")
+	delete(fields, "status")
+
+	return fields, nil
+}
+
+// prune drops everything from live that is not set in desired, so only the desired fields are compared.
+// Lists are pruned item by item, as long as both have the same length.
+func prune(desired, live any) any {
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		liveValue, ok := live.(map[string]any)
+		if !ok {
+			return live
+		}
+
+		pruned := make(map[string]any, len(desiredValue))
+		for key, value := range desiredValue {
+			if value == nil {
+				delete(desiredValue, key)
+				continue
+			}
+
+			if item, ok := liveValue[key]; ok {
+				pruned[key] = prune(value, item)
+			}
+		}
+
+		return pruned
+	case []any:
+		liveValue, ok := live.([]any)
+		if !ok || len(liveValue) != len(desiredValue) {
+			return live
+		}
+
+		pruned := make([]any, len(liveValue))
+		for i := range liveValue {
+			pruned[i] = prune(desiredValue[i], liveValue[i])
+		}
+
+		return pruned
+	default:
+		return live
+	}
+}
+
+func prefix(kind string, paths []string) []string {
+	prefixed := make([]string, 0, len(paths))
+	for _, path := range paths {
+		prefixed = append(prefixed, kind+path)
+	}
+
+	return prefixed
+}
+
+func summarize(paths []string) string {
+	if len(paths) <= maxEventPaths {
+		return strings.Join(paths, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(paths[:maxEventPaths], ", "), len(paths)-maxEventPaths)
+}
@@ -0,0 +1,141 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	appv1 "stackit.cloud/datalogger/api/v1"
+)
+
+func desiredDeployment() *appsv1.Deployment {
+	replicas := int32(2)
+
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "datalogger-42",
+			Namespace:   "my-namespace1",
+			Annotations: map[string]string{"app.stackit.cloud/last-applied-hash": "42"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "datalogger-42"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "datalogger-42"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "datalogger-container", Image: "kennethreitz/httpbin"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// liveDeployment returns the desired deployment as the api server would return it
+func liveDeployment() *appsv1.Deployment {
+	live := desiredDeployment()
+	live.TypeMeta = metav1.TypeMeta{}
+	live.ResourceVersion = "4711"
+	live.CreationTimestamp = metav1.Now()
+	live.Annotations["deployment.kubernetes.io/revision"] = "1"
+	live.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+	live.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	live.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullAlways
+	live.Spec.Template.Spec.Containers[0].TerminationMessagePath = "/dev/termination-log"
+	live.Status.ReadyReplicas = 2
+
+	return live
+}
+
+func TestPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(live *appsv1.Deployment)
+		paths []string
+	}{
+		{
+			name:  "server-defaults",
+			edit:  func(live *appsv1.Deployment) {},
+			paths: []string{},
+		},
+		{
+			name: "scaled-by-hand",
+			edit: func(live *appsv1.Deployment) {
+				replicas := int32(5)
+				live.Spec.Replicas = &replicas
+			},
+			paths: []string{"/spec/replicas"},
+		},
+		{
+			name: "image-edited",
+			edit: func(live *appsv1.Deployment) {
+				live.Spec.Template.Spec.Containers[0].Image = "nginx"
+			},
+			paths: []string{"/spec/template/spec/containers/0/image"},
+		},
+		{
+			name: "label-removed",
+			edit: func(live *appsv1.Deployment) {
+				live.Spec.Template.Labels = nil
+			},
+			paths: []string{"/spec/template/metadata/labels"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			live := liveDeployment()
+			test.edit(live)
+
+			paths, _, err := Paths(desiredDeployment(), live)
+			require.Nil(t, err)
+			require.EqualValues(t, test.paths, paths)
+		})
+	}
+}
+
+func TestDetectorDetect(t *testing.T) {
+	ctx := context.Background()
+
+	recorder := record.NewFakeRecorder(10)
+	detector := NewDetector(recorder)
+
+	dataLogger := &appv1.DataLogger{ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample-42", Namespace: "my-namespace1"}}
+
+	drifted, err := detector.Detect(ctx, dataLogger, desiredDeployment(), liveDeployment())
+	require.Nil(t, err)
+	require.False(t, drifted)
+	require.Len(t, recorder.Events, 0)
+
+	_, _, ok := detector.Pop(client.ObjectKeyFromObject(dataLogger))
+	require.False(t, ok)
+
+	live := liveDeployment()
+	live.Spec.Template.Spec.Containers[0].Image = "nginx"
+
+	drifted, err = detector.Detect(ctx, dataLogger, desiredDeployment(), live)
+	require.Nil(t, err)
+	require.True(t, drifted)
+	require.EqualValues(t,
+		"Warning Drifted Deployment datalogger-42 drifted from the desired state at /spec/template/spec/containers/0/image",
+		<-recorder.Events)
+
+	paths, driftTime, ok := detector.Pop(client.ObjectKeyFromObject(dataLogger))
+	require.True(t, ok)
+	require.False(t, driftTime.IsZero())
+	require.EqualValues(t, []string{"deployment/spec/template/spec/containers/0/image"}, paths)
+
+	_, _, ok = detector.Pop(client.ObjectKeyFromObject(dataLogger))
+	require.False(t, ok)
+}
@@ -0,0 +1,14 @@
+package pkg
+
+// Reasons of the events recorded by the reconcilers
+const (
+	EventReasonCreated          = "Created"
+	EventReasonUpdated          = "Updated"
+	EventReasonDeleteFailed     = "DeleteFailed"
+	EventReasonNamespaceCreated = "NamespaceCreated"
+	EventReasonFinalizeBlocked  = "FinalizeBlocked"
+	EventReasonRetained         = "Retained"
+	EventReasonOrphaned         = "Orphaned"
+	EventReasonDeletionRefused  = "DeletionRefused"
+	EventReasonFinalizeStuck    = "FinalizeStuck"
+)
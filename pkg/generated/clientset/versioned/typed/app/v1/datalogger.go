@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+	v1 "stackit.cloud/datalogger/api/v1"
+	scheme "stackit.cloud/datalogger/pkg/generated/clientset/versioned/scheme"
+)
+
+// DataLoggersGetter has a method to return a DataLoggerInterface.
+// A group's client should implement this interface.
+type DataLoggersGetter interface {
+	DataLoggers(namespace string) DataLoggerInterface
+}
+
+// DataLoggerInterface has methods to work with DataLogger resources.
+type DataLoggerInterface interface {
+	Create(ctx context.Context, dataLogger *v1.DataLogger, opts metav1.CreateOptions) (*v1.DataLogger, error)
+	Update(ctx context.Context, dataLogger *v1.DataLogger, opts metav1.UpdateOptions) (*v1.DataLogger, error)
+	UpdateStatus(ctx context.Context, dataLogger *v1.DataLogger, opts metav1.UpdateOptions) (*v1.DataLogger, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.DataLogger, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.DataLoggerList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DataLogger, err error)
+	DataLoggerExpansion
+}
+
+// dataLoggers implements DataLoggerInterface
+type dataLoggers struct {
+	client rest.Interface
+	ns     string
+}
+
+// newDataLoggers returns a DataLoggers
+func newDataLoggers(c *AppV1Client, namespace string) *dataLoggers {
+	return &dataLoggers{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the dataLogger, and returns the corresponding dataLogger object, and an error if there is any.
+func (c *dataLoggers) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.DataLogger, err error) {
+	result = &v1.DataLogger{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("dataloggers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of DataLoggers that match those selectors.
+func (c *dataLoggers) List(ctx context.Context, opts metav1.ListOptions) (result *v1.DataLoggerList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.DataLoggerList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("dataloggers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested dataLoggers.
+func (c *dataLoggers) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("dataloggers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a dataLogger and creates it.  Returns the server's representation of the dataLogger, and an error, if there is any.
+func (c *dataLoggers) Create(ctx context.Context, dataLogger *v1.DataLogger, opts metav1.CreateOptions) (result *v1.DataLogger, err error) {
+	result = &v1.DataLogger{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("dataloggers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(dataLogger).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a dataLogger and updates it. Returns the server's representation of the dataLogger, and an error, if there is any.
+func (c *dataLoggers) Update(ctx context.Context, dataLogger *v1.DataLogger, opts metav1.UpdateOptions) (result *v1.DataLogger, err error) {
+	result = &v1.DataLogger{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("dataloggers").
+		Name(dataLogger.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(dataLogger).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *dataLoggers) UpdateStatus(ctx context.Context, dataLogger *v1.DataLogger, opts metav1.UpdateOptions) (result *v1.DataLogger, err error) {
+	result = &v1.DataLogger{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("dataloggers").
+		Name(dataLogger.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(dataLogger).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the dataLogger and deletes it. Returns an error if one occurs.
+func (c *dataLoggers) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("dataloggers").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *dataLoggers) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("dataloggers").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched dataLogger.
+func (c *dataLoggers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DataLogger, err error) {
+	result = &v1.DataLogger{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("dataloggers").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
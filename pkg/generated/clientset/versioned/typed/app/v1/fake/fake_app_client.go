@@ -0,0 +1,24 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+	v1 "stackit.cloud/datalogger/pkg/generated/clientset/versioned/typed/app/v1"
+)
+
+type FakeAppV1 struct {
+	*testing.Fake
+}
+
+func (c *FakeAppV1) DataLoggers(namespace string) v1.DataLoggerInterface {
+	return &FakeDataLoggers{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeAppV1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
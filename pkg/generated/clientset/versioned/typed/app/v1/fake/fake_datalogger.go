@@ -0,0 +1,125 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1 "stackit.cloud/datalogger/api/v1"
+)
+
+// FakeDataLoggers implements DataLoggerInterface
+type FakeDataLoggers struct {
+	Fake *FakeAppV1
+	ns   string
+}
+
+var dataloggersResource = v1.SchemeGroupVersion.WithResource("dataloggers")
+
+var dataloggersKind = v1.SchemeGroupVersion.WithKind("DataLogger")
+
+// Get takes name of the dataLogger, and returns the corresponding dataLogger object, and an error if there is any.
+func (c *FakeDataLoggers) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.DataLogger, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(dataloggersResource, c.ns, name), &v1.DataLogger{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DataLogger), err
+}
+
+// List takes label and field selectors, and returns the list of DataLoggers that match those selectors.
+func (c *FakeDataLoggers) List(ctx context.Context, opts metav1.ListOptions) (result *v1.DataLoggerList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(dataloggersResource, dataloggersKind, c.ns, opts), &v1.DataLoggerList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.DataLoggerList{ListMeta: obj.(*v1.DataLoggerList).ListMeta}
+	for _, item := range obj.(*v1.DataLoggerList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested dataLoggers.
+func (c *FakeDataLoggers) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(dataloggersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a dataLogger and creates it.  Returns the server's representation of the dataLogger, and an error, if there is any.
+func (c *FakeDataLoggers) Create(ctx context.Context, dataLogger *v1.DataLogger, opts metav1.CreateOptions) (result *v1.DataLogger, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(dataloggersResource, c.ns, dataLogger), &v1.DataLogger{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DataLogger), err
+}
+
+// Update takes the representation of a dataLogger and updates it. Returns the server's representation of the dataLogger, and an error, if there is any.
+func (c *FakeDataLoggers) Update(ctx context.Context, dataLogger *v1.DataLogger, opts metav1.UpdateOptions) (result *v1.DataLogger, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(dataloggersResource, c.ns, dataLogger), &v1.DataLogger{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DataLogger), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDataLoggers) UpdateStatus(ctx context.Context, dataLogger *v1.DataLogger, opts metav1.UpdateOptions) (*v1.DataLogger, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(dataloggersResource, "status", c.ns, dataLogger), &v1.DataLogger{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DataLogger), err
+}
+
+// Delete takes name of the dataLogger and deletes it. Returns an error if one occurs.
+func (c *FakeDataLoggers) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(dataloggersResource, c.ns, name, opts), &v1.DataLogger{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDataLoggers) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(dataloggersResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.DataLoggerList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched dataLogger.
+func (c *FakeDataLoggers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DataLogger, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dataloggersResource, c.ns, name, pt, data, subresources...), &v1.DataLogger{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DataLogger), err
+}
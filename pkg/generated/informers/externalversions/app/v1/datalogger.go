@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	versioned "stackit.cloud/datalogger/pkg/generated/clientset/versioned"
+	internalinterfaces "stackit.cloud/datalogger/pkg/generated/informers/externalversions/internalinterfaces"
+	v1 "stackit.cloud/datalogger/pkg/generated/listers/app/v1"
+)
+
+// DataLoggerInformer provides access to a shared informer and lister for
+// DataLoggers.
+type DataLoggerInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.DataLoggerLister
+}
+
+type dataLoggerInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewDataLoggerInformer constructs a new informer for DataLogger type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewDataLoggerInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredDataLoggerInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredDataLoggerInformer constructs a new informer for DataLogger type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredDataLoggerInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AppV1().DataLoggers(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AppV1().DataLoggers(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&appv1.DataLogger{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *dataLoggerInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredDataLoggerInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *dataLoggerInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&appv1.DataLogger{}, f.defaultInformer)
+}
+
+func (f *dataLoggerInformer) Lister() v1.DataLoggerLister {
+	return v1.NewDataLoggerLister(f.Informer().GetIndexer())
+}
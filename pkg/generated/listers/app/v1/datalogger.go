@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	v1 "stackit.cloud/datalogger/api/v1"
+)
+
+// DataLoggerLister helps list DataLoggers.
+// All objects returned here must be treated as read-only.
+type DataLoggerLister interface {
+	// List lists all DataLoggers in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.DataLogger, err error)
+	// DataLoggers returns an object that can list and get DataLoggers.
+	DataLoggers(namespace string) DataLoggerNamespaceLister
+	DataLoggerListerExpansion
+}
+
+// dataLoggerLister implements the DataLoggerLister interface.
+type dataLoggerLister struct {
+	indexer cache.Indexer
+}
+
+// NewDataLoggerLister returns a new DataLoggerLister.
+func NewDataLoggerLister(indexer cache.Indexer) DataLoggerLister {
+	return &dataLoggerLister{indexer: indexer}
+}
+
+// List lists all DataLoggers in the indexer.
+func (s *dataLoggerLister) List(selector labels.Selector) (ret []*v1.DataLogger, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.DataLogger))
+	})
+	return ret, err
+}
+
+// DataLoggers returns an object that can list and get DataLoggers.
+func (s *dataLoggerLister) DataLoggers(namespace string) DataLoggerNamespaceLister {
+	return dataLoggerNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// DataLoggerNamespaceLister helps list and get DataLoggers.
+// All objects returned here must be treated as read-only.
+type DataLoggerNamespaceLister interface {
+	// List lists all DataLoggers in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.DataLogger, err error)
+	// Get retrieves the DataLogger from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.DataLogger, error)
+	DataLoggerNamespaceListerExpansion
+}
+
+// dataLoggerNamespaceLister implements the DataLoggerNamespaceLister
+// interface.
+type dataLoggerNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all DataLoggers in the indexer for a given namespace.
+func (s dataLoggerNamespaceLister) List(selector labels.Selector) (ret []*v1.DataLogger, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.DataLogger))
+	})
+	return ret, err
+}
+
+// Get retrieves the DataLogger from the indexer for a given namespace and name.
+func (s dataLoggerNamespaceLister) Get(name string) (*v1.DataLogger, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("datalogger"), name)
+	}
+	return obj.(*v1.DataLogger), nil
+}
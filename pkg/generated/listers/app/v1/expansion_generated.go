@@ -0,0 +1,11 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// DataLoggerListerExpansion allows custom methods to be added to
+// DataLoggerLister.
+type DataLoggerListerExpansion interface{}
+
+// DataLoggerNamespaceListerExpansion allows custom methods to be added to
+// DataLoggerNamespaceLister.
+type DataLoggerNamespaceListerExpansion interface{}
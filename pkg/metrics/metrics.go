@@ -0,0 +1,121 @@
+// Package metrics contains the prometheus metrics of the operator
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"stackit.cloud/datalogger/pkg/requeue"
+)
+
+// Results of a write of a managed object
+const (
+	ResultApplied = "applied"
+	ResultSkipped = "skipped"
+)
+
+// Sub-reconcilers of the operator, as used in the reconciler label
+const (
+	ReconcilerDeployment = "deployment"
+	ReconcilerService    = "service"
+	ReconcilerNamespace  = "namespace"
+	ReconcilerFinalize   = "finalize"
+)
+
+// Phases of a dataLogger, as used in the phase label
+const (
+	PhasePending    = "Pending"
+	PhaseReady      = "Ready"
+	PhaseFailed     = "Failed"
+	PhaseFinalizing = "Finalizing"
+)
+
+// Writes counts the writes of managed objects by kind and result. A write is skipped
+// when the object in the cluster already carries the hash of the desired state.
+var Writes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "datalogger_writes_total",
+	Help: "Number of applied and skipped writes of objects managed by the operator",
+}, []string{"kind", "result"})
+
+// ReconcileDuration observes how long a run of a sub-reconciler takes
+var ReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "datalogger_reconcile_duration_seconds",
+	Help:    "Duration of the runs of the sub-reconcilers of the operator",
+	Buckets: prometheus.DefBuckets,
+}, []string{"reconciler"})
+
+// ReconcileErrors counts the failed runs of a sub-reconciler by the class of the error
+var ReconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "datalogger_reconcile_errors_total",
+	Help: "Number of failed runs of the sub-reconcilers of the operator by error class",
+}, []string{"reconciler", "class"})
+
+// DataLoggers is the number of dataLoggers managed by the operator by phase
+var DataLoggers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "datalogger_dataloggers",
+	Help: "Number of DataLoggers managed by the operator by phase",
+}, []string{"phase"})
+
+// NamespacesCreated counts the namespaces created from the labels of a namespace
+var NamespacesCreated = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "datalogger_namespaces_created_total",
+	Help: "Number of namespaces created from the namespaces labels of a namespace",
+})
+
+// TimeToReady observes the time from the creation of a dataLogger until its Ready condition turns true
+// for the first time, once per dataLogger
+var TimeToReady = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Name:    "datalogger_time_to_ready_seconds",
+	Help:    "Seconds from the creation of a DataLogger until its Ready condition turns true for the first time",
+	Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+})
+
+// phases holds the last phase of every dataLogger, so that each one is counted once in DataLoggers
+var phases = struct {
+	sync.Mutex
+	byKey map[types.NamespacedName]string
+}{byKey: map[types.NamespacedName]string{}}
+
+func init() {
+	metrics.Registry.MustRegister(Writes, ReconcileDuration, ReconcileErrors, DataLoggers, NamespacesCreated, TimeToReady)
+}
+
+// ObserveReconcile records the duration of a run of the sub-reconciler that started at start and its error, if any
+func ObserveReconcile(reconciler string, start time.Time, err error) {
+	ReconcileDuration.WithLabelValues(reconciler).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		ReconcileErrors.WithLabelValues(reconciler, string(requeue.ClassOf(err))).Inc()
+	}
+}
+
+// SetPhase moves the dataLogger to the phase in DataLoggers
+func SetPhase(key types.NamespacedName, phase string) {
+	phases.Lock()
+	defer phases.Unlock()
+
+	if previous, ok := phases.byKey[key]; ok {
+		if previous == phase {
+			return
+		}
+
+		DataLoggers.WithLabelValues(previous).Dec()
+	}
+
+	phases.byKey[key] = phase
+	DataLoggers.WithLabelValues(phase).Inc()
+}
+
+// ForgetDataLogger removes a deleted dataLogger from DataLoggers
+func ForgetDataLogger(key types.NamespacedName) {
+	phases.Lock()
+	defer phases.Unlock()
+
+	if previous, ok := phases.byKey[key]; ok {
+		DataLoggers.WithLabelValues(previous).Dec()
+		delete(phases.byKey, key)
+	}
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// scrape gathers the registry of controller-runtime and returns the sample of the metric with the labels
+func scrape(t *testing.T, name string, labels map[string]string) *dto.Metric {
+	families, err := metrics.Registry.Gather()
+	require.Nil(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			matched := 0
+
+			for _, pair := range metric.GetLabel() {
+				if labels[pair.GetName()] == pair.GetValue() {
+					matched++
+				}
+			}
+
+			if matched == len(labels) {
+				return metric
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestObserveReconcile(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		class string
+	}{
+		{
+			name:  "success",
+			err:   nil,
+			class: "",
+		},
+		{
+			name:  "transient",
+			err:   errors.New("connection refused"),
+			class: "Transient",
+		},
+		{
+			name:  "conflict",
+			err:   apierrors.NewConflict(schema.GroupResource{Resource: "dataloggers"}, "datalogger-sample", errors.New("changed")),
+			class: "Conflict",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			reconciler := "test-" + test.name
+
+			ObserveReconcile(reconciler, time.Now().Add(-time.Second), test.err)
+
+			duration := scrape(t, "datalogger_reconcile_duration_seconds", map[string]string{"reconciler": reconciler})
+			require.NotNil(t, duration)
+			require.EqualValues(t, 1, duration.GetHistogram().GetSampleCount())
+			require.GreaterOrEqual(t, duration.GetHistogram().GetSampleSum(), 1.0)
+
+			failures := scrape(t, "datalogger_reconcile_errors_total", map[string]string{"reconciler": reconciler, "class": test.class})
+			if test.err == nil {
+				require.Nil(t, failures)
+			} else {
+				require.NotNil(t, failures)
+				require.EqualValues(t, 1, failures.GetCounter().GetValue())
+			}
+		})
+	}
+}
+
+func TestSetPhase(t *testing.T) {
+	first := types.NamespacedName{Name: "datalogger-sample", Namespace: "my-namespace1"}
+	second := types.NamespacedName{Name: "datalogger-sample", Namespace: "my-namespace2"}
+
+	gauge := func(phase string) float64 {
+		metric := scrape(t, "datalogger_dataloggers", map[string]string{"phase": phase})
+		if metric == nil {
+			return 0
+		}
+
+		return metric.GetGauge().GetValue()
+	}
+
+	SetPhase(first, PhasePending)
+	SetPhase(second, PhasePending)
+	require.EqualValues(t, 2, gauge(PhasePending))
+
+	SetPhase(first, PhaseReady)
+	SetPhase(first, PhaseReady)
+	require.EqualValues(t, 1, gauge(PhasePending))
+	require.EqualValues(t, 1, gauge(PhaseReady))
+
+	SetPhase(second, PhaseFailed)
+	SetPhase(first, PhaseFinalizing)
+	require.EqualValues(t, 0, gauge(PhasePending))
+	require.EqualValues(t, 0, gauge(PhaseReady))
+	require.EqualValues(t, 1, gauge(PhaseFailed))
+	require.EqualValues(t, 1, gauge(PhaseFinalizing))
+
+	ForgetDataLogger(first)
+	ForgetDataLogger(first)
+	ForgetDataLogger(second)
+	require.EqualValues(t, 0, gauge(PhaseFailed))
+	require.EqualValues(t, 0, gauge(PhaseFinalizing))
+}
+
+func TestMetricsRegistered(t *testing.T) {
+	NamespacesCreated.Inc()
+	TimeToReady.Observe(42)
+
+	created := scrape(t, "datalogger_namespaces_created_total", nil)
+	require.NotNil(t, created)
+	require.GreaterOrEqual(t, created.GetCounter().GetValue(), 1.0)
+
+	ready := scrape(t, "datalogger_time_to_ready_seconds", nil)
+	require.NotNil(t, ready)
+	require.EqualValues(t, 1, ready.GetHistogram().GetSampleCount())
+	require.EqualValues(t, 42, ready.GetHistogram().GetSampleSum())
+}
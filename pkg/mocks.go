@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	types "k8s.io/apimachinery/pkg/types"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 	reconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -76,6 +77,25 @@ func (mr *MockAPIClientOperatorMockRecorder) Delete(ctx, obj interface{}, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAPIClientOperator)(nil).Delete), varargs...)
 }
 
+// DeleteAllOf mocks base method.
+func (m *MockAPIClientOperator) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, obj}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteAllOf", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllOf indicates an expected call of DeleteAllOf.
+func (mr *MockAPIClientOperatorMockRecorder) DeleteAllOf(ctx, obj interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, obj}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllOf", reflect.TypeOf((*MockAPIClientOperator)(nil).DeleteAllOf), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockAPIClientOperator) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 	m.ctrl.T.Helper()
@@ -95,6 +115,44 @@ func (mr *MockAPIClientOperatorMockRecorder) Get(ctx, key, obj interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAPIClientOperator)(nil).Get), varargs...)
 }
 
+// List mocks base method.
+func (m *MockAPIClientOperator) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, list}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockAPIClientOperatorMockRecorder) List(ctx, list interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, list}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAPIClientOperator)(nil).List), varargs...)
+}
+
+// Patch mocks base method.
+func (m *MockAPIClientOperator) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, obj, patch}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockAPIClientOperatorMockRecorder) Patch(ctx, obj, patch interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, obj, patch}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockAPIClientOperator)(nil).Patch), varargs...)
+}
+
 // Scheme mocks base method.
 func (m *MockAPIClientOperator) Scheme() *runtime.Scheme {
 	m.ctrl.T.Helper()
@@ -109,6 +167,20 @@ func (mr *MockAPIClientOperatorMockRecorder) Scheme() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scheme", reflect.TypeOf((*MockAPIClientOperator)(nil).Scheme))
 }
 
+// Status mocks base method.
+func (m *MockAPIClientOperator) Status() client.SubResourceWriter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(client.SubResourceWriter)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockAPIClientOperatorMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockAPIClientOperator)(nil).Status))
+}
+
 // Update mocks base method.
 func (m *MockAPIClientOperator) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
 	m.ctrl.T.Helper()
@@ -128,6 +200,192 @@ func (mr *MockAPIClientOperatorMockRecorder) Update(ctx, obj interface{}, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAPIClientOperator)(nil).Update), varargs...)
 }
 
+// MockStatusWriterOperator is a mock of StatusWriterOperator interface.
+type MockStatusWriterOperator struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusWriterOperatorMockRecorder
+}
+
+// MockStatusWriterOperatorMockRecorder is the mock recorder for MockStatusWriterOperator.
+type MockStatusWriterOperatorMockRecorder struct {
+	mock *MockStatusWriterOperator
+}
+
+// NewMockStatusWriterOperator creates a new mock instance.
+func NewMockStatusWriterOperator(ctrl *gomock.Controller) *MockStatusWriterOperator {
+	mock := &MockStatusWriterOperator{ctrl: ctrl}
+	mock.recorder = &MockStatusWriterOperatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusWriterOperator) EXPECT() *MockStatusWriterOperatorMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatusWriterOperator) Create(ctx context.Context, obj, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, obj, subResource}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatusWriterOperatorMockRecorder) Create(ctx, obj, subResource interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, obj, subResource}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatusWriterOperator)(nil).Create), varargs...)
+}
+
+// Patch mocks base method.
+func (m *MockStatusWriterOperator) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, obj, patch}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockStatusWriterOperatorMockRecorder) Patch(ctx, obj, patch interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, obj, patch}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockStatusWriterOperator)(nil).Patch), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockStatusWriterOperator) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, obj}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStatusWriterOperatorMockRecorder) Update(ctx, obj interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, obj}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStatusWriterOperator)(nil).Update), varargs...)
+}
+
+// MockEventRecorderOperator is a mock of EventRecorderOperator interface.
+type MockEventRecorderOperator struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRecorderOperatorMockRecorder
+}
+
+// MockEventRecorderOperatorMockRecorder is the mock recorder for MockEventRecorderOperator.
+type MockEventRecorderOperatorMockRecorder struct {
+	mock *MockEventRecorderOperator
+}
+
+// NewMockEventRecorderOperator creates a new mock instance.
+func NewMockEventRecorderOperator(ctrl *gomock.Controller) *MockEventRecorderOperator {
+	mock := &MockEventRecorderOperator{ctrl: ctrl}
+	mock.recorder = &MockEventRecorderOperatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRecorderOperator) EXPECT() *MockEventRecorderOperatorMockRecorder {
+	return m.recorder
+}
+
+// Event mocks base method.
+func (m *MockEventRecorderOperator) Event(object runtime.Object, eventtype, reason, message string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Event", object, eventtype, reason, message)
+}
+
+// Event indicates an expected call of Event.
+func (mr *MockEventRecorderOperatorMockRecorder) Event(object, eventtype, reason, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Event", reflect.TypeOf((*MockEventRecorderOperator)(nil).Event), object, eventtype, reason, message)
+}
+
+// Eventf mocks base method.
+func (m *MockEventRecorderOperator) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{object, eventtype, reason, messageFmt}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Eventf", varargs...)
+}
+
+// Eventf indicates an expected call of Eventf.
+func (mr *MockEventRecorderOperatorMockRecorder) Eventf(object, eventtype, reason, messageFmt interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{object, eventtype, reason, messageFmt}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Eventf", reflect.TypeOf((*MockEventRecorderOperator)(nil).Eventf), varargs...)
+}
+
+// MockDriftOperator is a mock of DriftOperator interface.
+type MockDriftOperator struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriftOperatorMockRecorder
+}
+
+// MockDriftOperatorMockRecorder is the mock recorder for MockDriftOperator.
+type MockDriftOperatorMockRecorder struct {
+	mock *MockDriftOperator
+}
+
+// NewMockDriftOperator creates a new mock instance.
+func NewMockDriftOperator(ctrl *gomock.Controller) *MockDriftOperator {
+	mock := &MockDriftOperator{ctrl: ctrl}
+	mock.recorder = &MockDriftOperatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriftOperator) EXPECT() *MockDriftOperatorMockRecorder {
+	return m.recorder
+}
+
+// Detect mocks base method.
+func (m *MockDriftOperator) Detect(ctx context.Context, owner, desired, live client.Object) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detect", ctx, owner, desired, live)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detect indicates an expected call of Detect.
+func (mr *MockDriftOperatorMockRecorder) Detect(ctx, owner, desired, live interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detect", reflect.TypeOf((*MockDriftOperator)(nil).Detect), ctx, owner, desired, live)
+}
+
+// Pop mocks base method.
+func (m *MockDriftOperator) Pop(key types.NamespacedName) ([]string, v1.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pop", key)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(v1.Time)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
+}
+
+// Pop indicates an expected call of Pop.
+func (mr *MockDriftOperatorMockRecorder) Pop(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pop", reflect.TypeOf((*MockDriftOperator)(nil).Pop), key)
+}
+
 // MockLogOperator is a mock of LogOperator interface.
 type MockLogOperator struct {
 	ctrl     *gomock.Controller
@@ -209,17 +467,17 @@ func (m *MockReconcileOperator) EXPECT() *MockReconcileOperatorMockRecorder {
 }
 
 // Reconcile mocks base method.
-func (m *MockReconcileOperator) Reconcile(ctx context.Context, req reconcile.Request, r APIClientOperator) error {
+func (m *MockReconcileOperator) Reconcile(ctx context.Context, scope *Scope, r APIClientOperator) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Reconcile", ctx, req, r)
+	ret := m.ctrl.Call(m, "Reconcile", ctx, scope, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Reconcile indicates an expected call of Reconcile.
-func (mr *MockReconcileOperatorMockRecorder) Reconcile(ctx, req, r interface{}) *gomock.Call {
+func (mr *MockReconcileOperatorMockRecorder) Reconcile(ctx, scope, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockReconcileOperator)(nil).Reconcile), ctx, req, r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockReconcileOperator)(nil).Reconcile), ctx, scope, r)
 }
 
 // MockDeploymentOperator is a mock of DeploymentOperator interface.
@@ -246,17 +504,17 @@ func (m *MockDeploymentOperator) EXPECT() *MockDeploymentOperatorMockRecorder {
 }
 
 // Reconcile mocks base method.
-func (m *MockDeploymentOperator) Reconcile(ctx context.Context, req reconcile.Request, r APIClientOperator) error {
+func (m *MockDeploymentOperator) Reconcile(ctx context.Context, scope *Scope, r APIClientOperator) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Reconcile", ctx, req, r)
+	ret := m.ctrl.Call(m, "Reconcile", ctx, scope, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Reconcile indicates an expected call of Reconcile.
-func (mr *MockDeploymentOperatorMockRecorder) Reconcile(ctx, req, r interface{}) *gomock.Call {
+func (mr *MockDeploymentOperatorMockRecorder) Reconcile(ctx, scope, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockDeploymentOperator)(nil).Reconcile), ctx, req, r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockDeploymentOperator)(nil).Reconcile), ctx, scope, r)
 }
 
 // MockServiceOperator is a mock of ServiceOperator interface.
@@ -283,17 +541,17 @@ func (m *MockServiceOperator) EXPECT() *MockServiceOperatorMockRecorder {
 }
 
 // Reconcile mocks base method.
-func (m *MockServiceOperator) Reconcile(ctx context.Context, req reconcile.Request, r APIClientOperator) error {
+func (m *MockServiceOperator) Reconcile(ctx context.Context, scope *Scope, r APIClientOperator) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Reconcile", ctx, req, r)
+	ret := m.ctrl.Call(m, "Reconcile", ctx, scope, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Reconcile indicates an expected call of Reconcile.
-func (mr *MockServiceOperatorMockRecorder) Reconcile(ctx, req, r interface{}) *gomock.Call {
+func (mr *MockServiceOperatorMockRecorder) Reconcile(ctx, scope, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockServiceOperator)(nil).Reconcile), ctx, req, r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockServiceOperator)(nil).Reconcile), ctx, scope, r)
 }
 
 // MockNamespaceOperator is a mock of NamespaceOperator interface.
@@ -393,16 +651,16 @@ func (m *MockServiceReferenceController) EXPECT() *MockServiceReferenceControlle
 	return m.recorder
 }
 
-// IsControlledBy mocks base method.
-func (m *MockServiceReferenceController) IsControlledBy(obj, owner v1.Object) bool {
+// SetControllerReference mocks base method.
+func (m *MockServiceReferenceController) SetControllerReference(owner, controlled v1.Object, scheme *runtime.Scheme) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsControlledBy", obj, owner)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "SetControllerReference", owner, controlled, scheme)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IsControlledBy indicates an expected call of IsControlledBy.
-func (mr *MockServiceReferenceControllerMockRecorder) IsControlledBy(obj, owner interface{}) *gomock.Call {
+// SetControllerReference indicates an expected call of SetControllerReference.
+func (mr *MockServiceReferenceControllerMockRecorder) SetControllerReference(owner, controlled, scheme interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsControlledBy", reflect.TypeOf((*MockServiceReferenceController)(nil).IsControlledBy), obj, owner)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetControllerReference", reflect.TypeOf((*MockServiceReferenceController)(nil).SetControllerReference), owner, controlled, scheme)
 }
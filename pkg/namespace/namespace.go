@@ -3,6 +3,7 @@ package namespace
 import (
 	"context"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -11,71 +12,88 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/metrics"
+	"stackit.cloud/datalogger/pkg/utils/apply"
 )
 
 type Namespace struct {
-	logger pkg.LogOperator
+	logger   pkg.LogOperator
+	recorder pkg.EventRecorderOperator
 }
 
-func NewNamespaceReconciler(logger pkg.LogOperator) *Namespace {
-	return &Namespace{logger: logger}
+func NewNamespaceReconciler(logger pkg.LogOperator, recorder pkg.EventRecorderOperator) *Namespace {
+	return &Namespace{logger: logger, recorder: recorder}
 }
 
 func (n Namespace) Reconcile(ctx context.Context, req ctrl.Request, apiClient pkg.APIClientOperator) error {
+	start := time.Now()
 	namespace := &corev1.Namespace{}
 
 	err := apiClient.Get(ctx, req.NamespacedName, namespace)
 	if err != nil {
 		if !errors.IsNotFound(err) {
-			n.logger.Error(err, "unable to fetch dataLogger CRD namespace", req.Name, req.Namespace)
+			n.logger.Error(err, "unable to fetch dataLogger CRD namespace", "name", req.Name, "namespace", req.Namespace)
+			metrics.ObserveReconcile(metrics.ReconcilerNamespace, start, err)
 		}
 
 		return err
 	}
 
-	labels := namespace.GetLabels()
-
-	err = n.createNamespaces(ctx, labels, apiClient)
-	if err != nil {
-		return err
-	}
+	err = n.createNamespaces(ctx, namespace, apiClient)
+	metrics.ObserveReconcile(metrics.ReconcilerNamespace, start, err)
 
 	return err
 }
 
-func (Namespace) createNamespaces(ctx context.Context, labels map[string]string, r pkg.APIClientOperator) error {
+// createNamespaces creates the namespaces listed in the labels of the source namespace
+func (n Namespace) createNamespaces(ctx context.Context, source *corev1.Namespace, r pkg.APIClientOperator) error {
 	logger := log.FromContext(ctx)
 
-	for key, value := range labels {
-		// Assuming the label keys start with "namespaces"
-		if key != "name" && strings.HasPrefix(key, "namespaces") {
-			namespaceName := value
+	for key, value := range source.GetLabels() {
+		if namespace, ok := ListedNamespace(key, value); ok {
+			namespaceName := namespace.Name
 
-			// Check if the namespace already exists
+			// Check if the namespace already exists, only to tell a creation apart in the logs
 			existingNamespace := &corev1.Namespace{}
-
 			err := r.Get(ctx, client.ObjectKey{Name: namespaceName}, existingNamespace)
-			if err != nil && errors.IsNotFound(err) {
-				// Namespace does not exist, create it
-				namespace := &corev1.Namespace{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: namespaceName,
-					},
-				}
-
-				errCreate := r.Create(ctx, namespace)
-				if errCreate != nil {
-					logger.Error(errCreate, "error creating namespace", "namespace", namespaceName)
-					return errCreate
-				}
-
-				logger.Info("Namespace created successfully", "namespace", namespaceName)
-			} else if err != nil {
+			if err != nil && !errors.IsNotFound(err) {
 				logger.Error(err, "error checking if namespace exists", "namespace", namespaceName)
 				return err
 			}
+
+			errApply := apply.Patch(ctx, r, namespace)
+			if errApply != nil {
+				logger.Error(errApply, "error applying namespace", "namespace", namespaceName)
+				return errApply
+			}
+
+			if err != nil {
+				logger.Info("Namespace created successfully", "namespace", namespaceName)
+				metrics.NamespacesCreated.Inc()
+
+				n.recorder.Eventf(source, corev1.EventTypeNormal, pkg.EventReasonNamespaceCreated,
+					"Created namespace %s listed in label %s", namespaceName, key)
+			}
 		}
 	}
 
 	return nil
 }
+
+// ListedNamespace returns the namespace listed in the label of a namespace, if the label lists one
+func ListedNamespace(key, value string) (*corev1.Namespace, bool) {
+	// Assuming the label keys start with "namespaces"
+	if key == "name" || !strings.HasPrefix(key, "namespaces") {
+		return nil, false
+	}
+
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: value,
+		},
+	}, true
+}
@@ -14,10 +14,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/utils/apply"
 )
 
 func TestNamespaceReconcileWithNoErrors(t *testing.T) {
@@ -28,7 +30,8 @@ func TestNamespaceReconcileWithNoErrors(t *testing.T) {
 	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
 	logger := pkg.NewMockLogOperator(mockCtrl)
 
-	reconciler := NewNamespaceReconciler(logger)
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewNamespaceReconciler(logger, recorder)
 
 	tests := []struct {
 		name        string
@@ -88,6 +91,10 @@ func TestNamespaceReconcileWithNoErrors(t *testing.T) {
 			apiClient.EXPECT().Get(
 				ctx, client.ObjectKey{Name: test.namespace}, ns1).Times(1).Return(test.errorValue1)
 
+			apiClient.EXPECT().Patch(
+				ctx, appliedNamespace(test.namespace), client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+			).Times(1).Return(test.errorValue2)
+
 			err := reconciler.Reconcile(ctx, req, apiClient)
 			require.Nil(t, err)
 		})
@@ -102,7 +109,8 @@ func TestNamespaceReconcileWithErrors(t *testing.T) {
 	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
 	logger := pkg.NewMockLogOperator(mockCtrl)
 
-	reconciler := NewNamespaceReconciler(logger)
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewNamespaceReconciler(logger, recorder)
 
 	tests := []struct {
 		name        string
@@ -153,8 +161,8 @@ func TestNamespaceReconcileWithErrors(t *testing.T) {
 				logger.EXPECT().Error(
 					errors.New("unable to fetch dataLogger CRD namespace"),
 					"unable to fetch dataLogger CRD namespace",
-					test.name,
-					test.namespace).
+					"name", test.name,
+					"namespace", test.namespace).
 					Times(1)
 
 				err := reconciler.Reconcile(ctx, req, apiClient)
@@ -186,14 +194,9 @@ func TestNamespaceReconcileWithErrors(t *testing.T) {
 				apiClient.EXPECT().Get(
 					ctx, client.ObjectKey{Name: test.namespace}, ns1).Times(1).Return(test.notFound)
 
-				namespace := &corev1.Namespace{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: test.namespace,
-					},
-				}
-
-				apiClient.EXPECT().Create(
-					ctx, namespace).Times(1).Return(test.errorValue2)
+				apiClient.EXPECT().Patch(
+					ctx, appliedNamespace(test.namespace), client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+				).Times(1).Return(test.errorValue2)
 
 				err := reconciler.Reconcile(ctx, req, apiClient)
 				require.EqualValues(t, err, test.errorValue2)
@@ -201,3 +204,77 @@ func TestNamespaceReconcileWithErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestNamespaceReconcileRecordsCreation(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+
+	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+	logger := pkg.NewMockLogOperator(mockCtrl)
+
+	tests := []struct {
+		name      string
+		namespace string
+		existing  error
+		events    []string
+	}{
+		{
+			name:      "DataLoggerController-1",
+			namespace: "my-namespace1",
+			existing:  errors2.NewNotFound(schema.GroupResource{Group: "", Resource: "namespaces"}, "my-namespace1"),
+			events:    []string{"Normal NamespaceCreated Created namespace my-namespace1 listed in label namespaces1"},
+		},
+		{
+			name:      "DataLoggerController-2",
+			namespace: "my-namespace2",
+			existing:  nil,
+			events:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewNamespaceReconciler(logger, recorder)
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: test.name}}
+
+			apiClient.EXPECT().Get(
+				ctx, client.ObjectKey{Name: test.name}, &corev1.Namespace{}, gomock.Any(),
+			).Times(1).Do(
+				func(ctx context.Context, key client.ObjectKey, obj *corev1.Namespace, opts ...interface{}) error {
+					obj.ObjectMeta.Name = test.name
+					obj.ObjectMeta.Labels = map[string]string{"namespaces1": test.namespace}
+
+					return nil
+				}).Return(nil)
+
+			apiClient.EXPECT().Get(
+				ctx, client.ObjectKey{Name: test.namespace}, &corev1.Namespace{}).Times(1).Return(test.existing)
+
+			apiClient.EXPECT().Patch(
+				ctx, appliedNamespace(test.namespace), client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+			).Times(1).Return(nil)
+
+			err := reconciler.Reconcile(ctx, req, apiClient)
+			require.Nil(t, err)
+
+			for _, event := range test.events {
+				require.EqualValues(t, event, <-recorder.Events)
+			}
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
+
+// appliedNamespace returns the apply payload of a namespace
+func appliedNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
@@ -0,0 +1,393 @@
+// Package plugin implements the commands of the kubectl-datalogger plugin
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/datalogger"
+	"stackit.cloud/datalogger/pkg/deployment"
+)
+
+// AppLabel selects the pods and the service of a dataLogger by its custom name
+const AppLabel = "app"
+
+type Plugin struct {
+	apiClient pkg.APIClientOperator
+	clientset kubernetes.Interface
+	out       io.Writer
+}
+
+func NewPlugin(apiClient pkg.APIClientOperator, clientset kubernetes.Interface, out io.Writer) *Plugin {
+	return &Plugin{apiClient: apiClient, clientset: clientset, out: out}
+}
+
+// PortForwardTarget is a ready pod of a dataLogger with the ports to forward
+type PortForwardTarget struct {
+	Pod *corev1.Pod
+	// Port is spec.port of the dataLogger, the local port by default
+	Port int32
+	// TargetPort is the port of the pod the service forwards Port to
+	TargetPort int32
+}
+
+// children are the objects of a dataLogger in the cluster, the ones that do not exist are nil
+type children struct {
+	deployment *appsv1.Deployment
+	service    *corev1.Service
+	pods       []corev1.Pod
+}
+
+// List prints the dataLoggers of the namespace, or of all namespaces if it is empty, with their
+// readiness, replicas and endpoint
+func (p *Plugin) List(ctx context.Context, namespace string) error {
+	dataLoggers := &appv1.DataLoggerList{}
+	if err := p.apiClient.List(ctx, dataLoggers, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(p.out, 0, 4, 3, ' ', 0)
+	fmt.Fprintln(writer, "NAMESPACE\tNAME\tREADY\tREPLICAS\tENDPOINT\tAGE")
+
+	for i := range dataLoggers.Items {
+		dataLogger := &dataLoggers.Items[i]
+
+		found, err := p.children(ctx, dataLogger, false)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			dataLogger.Namespace, dataLogger.Name, ready(dataLogger), replicas(dataLogger, found), endpoint(dataLogger, found),
+			age(dataLogger.CreationTimestamp))
+	}
+
+	return writer.Flush()
+}
+
+// Describe prints the dataLogger with its conditions, the tree of its children and their events
+func (p *Plugin) Describe(ctx context.Context, key types.NamespacedName) error {
+	dataLogger := &appv1.DataLogger{}
+	if err := p.apiClient.Get(ctx, key, dataLogger); err != nil {
+		return err
+	}
+
+	found, err := p.children(ctx, dataLogger, true)
+	if err != nil {
+		return err
+	}
+
+	out := &strings.Builder{}
+
+	fmt.Fprintf(out, "DataLogger %s/%s\n", dataLogger.Namespace, dataLogger.Name)
+	fmt.Fprintf(out, "  Ready:     %s\n", ready(dataLogger))
+	fmt.Fprintf(out, "  Replicas:  %s\n", replicas(dataLogger, found))
+	fmt.Fprintf(out, "  Endpoint:  %s\n", endpoint(dataLogger, found))
+
+	if len(dataLogger.Status.Conditions) > 0 {
+		fmt.Fprintln(out, "  Conditions:")
+
+		for _, condition := range dataLogger.Status.Conditions {
+			fmt.Fprintf(out, "    %s=%s  %s: %s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+		}
+	}
+
+	fmt.Fprintln(out, "Children:")
+
+	if found.deployment == nil {
+		fmt.Fprintf(out, "├── Deployment %s (missing)\n", dataLogger.Spec.CustomName)
+	} else {
+		fmt.Fprintf(out, "├── Deployment %s  %d/%d ready\n",
+			found.deployment.Name, found.deployment.Status.ReadyReplicas, found.deployment.Status.Replicas)
+	}
+
+	for i, pod := range found.pods {
+		branch := "├──"
+		if i == len(found.pods)-1 {
+			branch = "└──"
+		}
+
+		fmt.Fprintf(out, "│   %s Pod %s  %s%s\n", branch, pod.Name, pod.Status.Phase, podReadiness(&pod))
+	}
+
+	if found.service == nil {
+		fmt.Fprintf(out, "└── Service %s (missing)\n", dataLogger.Spec.CustomName)
+	} else {
+		fmt.Fprintf(out, "└── Service %s  %s  %s\n", found.service.Name, found.service.Spec.Type, datalogger.Endpoint(found.service))
+	}
+
+	events, err := p.events(ctx, dataLogger, found)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "Events:")
+
+	if len(events) == 0 {
+		fmt.Fprintln(out, "  <none>")
+	}
+
+	for _, event := range events {
+		fmt.Fprintf(out, "  %s  %s  %s/%s  %s\n",
+			event.Type, event.Reason, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Message)
+	}
+
+	_, err = io.WriteString(p.out, out.String())
+
+	return err
+}
+
+// Logs streams the logs of the logger container of all pods of the dataLogger, every line prefixed
+// with the name of its pod. With follow it returns once all streams are closed or ctx is done.
+func (p *Plugin) Logs(ctx context.Context, key types.NamespacedName, follow bool) error {
+	dataLogger := &appv1.DataLogger{}
+	if err := p.apiClient.Get(ctx, key, dataLogger); err != nil {
+		return err
+	}
+
+	pods, err := p.pods(ctx, dataLogger)
+	if err != nil {
+		return err
+	}
+
+	if len(pods) == 0 {
+		return fmt.Errorf("dataLogger %s has no pods", key)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	errs := make([]error, len(pods))
+
+	for i := range pods {
+		wg.Add(1)
+
+		go func(i int, pod *corev1.Pod) {
+			defer wg.Done()
+
+			stream, err := p.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: deployment.ContainerName,
+				Follow:    follow,
+			}).Stream(ctx)
+			if err != nil {
+				errs[i] = fmt.Errorf("unable to stream the logs of pod %s: %w", pod.Name, err)
+				return
+			}
+			defer stream.Close()
+
+			scanner := bufio.NewScanner(stream)
+			for scanner.Scan() {
+				mu.Lock()
+				fmt.Fprintf(p.out, "[%s] %s\n", pod.Name, scanner.Text())
+				mu.Unlock()
+			}
+
+			if err = scanner.Err(); err != nil && ctx.Err() == nil {
+				errs[i] = fmt.Errorf("unable to read the logs of pod %s: %w", pod.Name, err)
+			}
+		}(i, &pods[i])
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PortForwardTarget returns a ready pod of the dataLogger, spec.port and the port its service forwards spec.port to
+func (p *Plugin) PortForwardTarget(ctx context.Context, key types.NamespacedName) (*PortForwardTarget, error) {
+	dataLogger := &appv1.DataLogger{}
+	if err := p.apiClient.Get(ctx, key, dataLogger); err != nil {
+		return nil, err
+	}
+
+	pods, err := p.pods(ctx, dataLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	// The target port defaults to the port, as in the defaulting webhook
+	target := &PortForwardTarget{Port: dataLogger.Spec.Port, TargetPort: dataLogger.Spec.TargetPort}
+	if target.TargetPort == 0 {
+		target.TargetPort = target.Port
+	}
+
+	if target.Port == 0 {
+		target.Port = target.TargetPort
+	}
+
+	for i := range pods {
+		if pods[i].Status.Phase == corev1.PodRunning && podReady(&pods[i]) {
+			target.Pod = &pods[i]
+			return target, nil
+		}
+	}
+
+	return nil, fmt.Errorf("dataLogger %s has no ready pod", key)
+}
+
+// children fetches the deployment and the service of the dataLogger, and its pods if withPods is set
+func (p *Plugin) children(ctx context.Context, dataLogger *appv1.DataLogger, withPods bool) (children, error) {
+	found := children{}
+	key := client.ObjectKey{Name: dataLogger.Spec.CustomName, Namespace: dataLogger.Namespace}
+
+	deployment := &appsv1.Deployment{}
+
+	err := p.apiClient.Get(ctx, key, deployment)
+	if client.IgnoreNotFound(err) != nil {
+		return found, err
+	}
+
+	if err == nil {
+		found.deployment = deployment
+	}
+
+	service := &corev1.Service{}
+
+	err = p.apiClient.Get(ctx, key, service)
+	if client.IgnoreNotFound(err) != nil {
+		return found, err
+	}
+
+	if err == nil {
+		found.service = service
+	}
+
+	if withPods {
+		if found.pods, err = p.pods(ctx, dataLogger); err != nil {
+			return found, err
+		}
+	}
+
+	return found, nil
+}
+
+// pods lists the pods of the dataLogger by their app label, sorted by name
+func (p *Plugin) pods(ctx context.Context, dataLogger *appv1.DataLogger) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+
+	err := p.apiClient.List(ctx, pods,
+		client.InNamespace(dataLogger.Namespace), client.MatchingLabels{AppLabel: dataLogger.Spec.CustomName})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+
+	return pods.Items, nil
+}
+
+// events returns the events of the dataLogger and its children, the oldest first
+func (p *Plugin) events(ctx context.Context, dataLogger *appv1.DataLogger, found children) ([]corev1.Event, error) {
+	list := &corev1.EventList{}
+	if err := p.apiClient.List(ctx, list, client.InNamespace(dataLogger.Namespace)); err != nil {
+		return nil, err
+	}
+
+	involved := map[string]bool{"DataLogger/" + dataLogger.Name: true}
+	if found.deployment != nil {
+		involved["Deployment/"+found.deployment.Name] = true
+	}
+
+	if found.service != nil {
+		involved["Service/"+found.service.Name] = true
+	}
+
+	for _, pod := range found.pods {
+		involved["Pod/"+pod.Name] = true
+	}
+
+	events := []corev1.Event{}
+
+	for _, event := range list.Items {
+		if involved[event.InvolvedObject.Kind+"/"+event.InvolvedObject.Name] {
+			events = append(events, event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+	})
+
+	return events, nil
+}
+
+// ready returns the status of the Ready condition of the dataLogger
+func ready(dataLogger *appv1.DataLogger) string {
+	condition := meta.FindStatusCondition(dataLogger.Status.Conditions, appv1.ConditionReady)
+	if condition == nil {
+		return string(metav1.ConditionUnknown)
+	}
+
+	return string(condition.Status)
+}
+
+// replicas returns the ready and desired replicas, from the deployment if it exists
+func replicas(dataLogger *appv1.DataLogger, found children) string {
+	if found.deployment != nil && found.deployment.Spec.Replicas != nil {
+		return fmt.Sprintf("%d/%d", found.deployment.Status.ReadyReplicas, *found.deployment.Spec.Replicas)
+	}
+
+	return fmt.Sprintf("%d/%d", dataLogger.Status.ReadyReplicas, dataLogger.Status.Replicas)
+}
+
+// endpoint returns the endpoint of the status, or of the service if the status has none yet
+func endpoint(dataLogger *appv1.DataLogger, found children) string {
+	if dataLogger.Status.Endpoint != "" {
+		return dataLogger.Status.Endpoint
+	}
+
+	if found.service != nil {
+		return datalogger.Endpoint(found.service)
+	}
+
+	return "<none>"
+}
+
+func age(created metav1.Time) string {
+	if created.IsZero() {
+		return "<unknown>"
+	}
+
+	return duration.HumanDuration(time.Since(created.Time))
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func podReadiness(pod *corev1.Pod) string {
+	if podReady(pod) {
+		return "  ready"
+	}
+
+	return "  not ready"
+}
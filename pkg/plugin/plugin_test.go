@@ -0,0 +1,196 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg/render"
+)
+
+// cluster returns the objects of a ready dataLogger with two pods, one of them not ready yet
+func cluster() []client.Object {
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample-42", Namespace: "my-namespace1"},
+		Spec:       appv1.DataLoggerSpec{CustomName: "datalogger-42", Port: 8080, TargetPort: 80, Replicas: 2},
+		Status: appv1.DataLoggerStatus{
+			Conditions: []metav1.Condition{
+				{Type: appv1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Available", Message: "all resources are available"},
+			},
+			Replicas:      2,
+			ReadyReplicas: 1,
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-42", Namespace: "my-namespace1"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+		Status:     appsv1.DeploymentStatus{Replicas: 2, ReadyReplicas: 1},
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-42", Namespace: "my-namespace1"},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{{Port: 8080, TargetPort: intstr.FromInt32(80)}},
+		},
+	}
+
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-namespace1", Labels: map[string]string{AppLabel: "datalogger-42"}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "datalogger-sample-42.1", Namespace: "my-namespace1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "DataLogger", Name: "datalogger-sample-42"},
+		Type:           corev1.EventTypeNormal,
+		Reason:         "Created",
+		Message:        "Created Deployment datalogger-42",
+	}
+
+	// Neither the pod nor the event of another dataLogger are shown
+	other := pod("datalogger-43-x", corev1.ConditionTrue)
+	other.Labels[AppLabel] = "datalogger-43"
+
+	otherEvent := event.DeepCopy()
+	otherEvent.Name = "datalogger-sample-43.1"
+	otherEvent.InvolvedObject.Name = "datalogger-sample-43"
+
+	return []client.Object{
+		dataLogger, deployment, service,
+		pod("datalogger-42-b", corev1.ConditionFalse), pod("datalogger-42-a", corev1.ConditionTrue), other,
+		event, otherEvent,
+	}
+}
+
+func newPlugin(out *bytes.Buffer, objs ...client.Object) *Plugin {
+	apiClient := fake.NewClientBuilder().WithScheme(render.Scheme).WithObjects(objs...).Build()
+
+	pods := []runtime.Object{}
+	for _, obj := range objs {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+
+	return NewPlugin(apiClient, kubernetesfake.NewSimpleClientset(pods...), out)
+}
+
+func TestPluginList(t *testing.T) {
+	ctx := context.Background()
+
+	pending := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{Name: "datalogger-sample-0001", Namespace: "my-namespace2"},
+		Spec:       appv1.DataLoggerSpec{CustomName: "datalogger-0001"},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		rows      []string
+	}{
+		{
+			name:      "namespace",
+			namespace: "my-namespace1",
+			rows: []string{
+				"my-namespace1   datalogger-sample-42   True    1/2        datalogger-42.my-namespace1.svc:8080",
+			},
+		},
+		{
+			name:      "all-namespaces",
+			namespace: "",
+			rows: []string{
+				"my-namespace1   datalogger-sample-42     True      1/2        datalogger-42.my-namespace1.svc:8080",
+				"my-namespace2   datalogger-sample-0001   Unknown   0/0        <none>",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			out := &bytes.Buffer{}
+			require.Nil(t, newPlugin(out, append(cluster(), pending)...).List(ctx, test.namespace))
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			require.Len(t, lines, len(test.rows)+1)
+			require.True(t, strings.HasPrefix(lines[0], "NAMESPACE"))
+
+			for i, row := range test.rows {
+				require.True(t, strings.HasPrefix(lines[i+1], row), lines[i+1])
+			}
+		})
+	}
+}
+
+func TestPluginDescribe(t *testing.T) {
+	ctx := context.Background()
+
+	out := &bytes.Buffer{}
+	err := newPlugin(out, cluster()...).Describe(ctx, types.NamespacedName{Name: "datalogger-sample-42", Namespace: "my-namespace1"})
+	require.Nil(t, err)
+
+	require.EqualValues(t, `DataLogger my-namespace1/datalogger-sample-42
+  Ready:     True
+  Replicas:  1/2
+  Endpoint:  datalogger-42.my-namespace1.svc:8080
+  Conditions:
+    Ready=True  Available: all resources are available
+Children:
+├── Deployment datalogger-42  1/2 ready
+│   ├── Pod datalogger-42-a  Running  ready
+│   └── Pod datalogger-42-b  Running  not ready
+└── Service datalogger-42  NodePort  datalogger-42.my-namespace1.svc:8080
+Events:
+  Normal  Created  DataLogger/datalogger-sample-42  Created Deployment datalogger-42
+`, out.String())
+}
+
+func TestPluginLogs(t *testing.T) {
+	ctx := context.Background()
+
+	out := &bytes.Buffer{}
+	err := newPlugin(out, cluster()...).Logs(ctx, types.NamespacedName{Name: "datalogger-sample-42", Namespace: "my-namespace1"}, false)
+	require.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.ElementsMatch(t, []string{"[datalogger-42-a] fake logs", "[datalogger-42-b] fake logs"}, lines)
+}
+
+func TestPluginPortForwardTarget(t *testing.T) {
+	ctx := context.Background()
+
+	// The service listens on 8080 and forwards to 80 in the pod
+	target, err := newPlugin(&bytes.Buffer{}, cluster()...).PortForwardTarget(
+		ctx, types.NamespacedName{Name: "datalogger-sample-42", Namespace: "my-namespace1"})
+	require.Nil(t, err)
+	require.EqualValues(t, "datalogger-42-a", target.Pod.Name)
+	require.EqualValues(t, 8080, target.Port)
+	require.EqualValues(t, 80, target.TargetPort)
+
+	_, err = newPlugin(&bytes.Buffer{}, cluster()[:3]...).PortForwardTarget(
+		ctx, types.NamespacedName{Name: "datalogger-sample-42", Namespace: "my-namespace1"})
+	require.EqualError(t, err, "dataLogger my-namespace1/datalogger-sample-42 has no ready pod")
+}
@@ -5,16 +5,35 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 type APIClientOperator interface {
 	Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
+	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
 	Scheme() *runtime.Scheme
 	Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error
 	Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error
 	Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error
+	DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error
+	Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error
+	Status() client.SubResourceWriter
+}
+
+type StatusWriterOperator interface {
+	client.SubResourceWriter
+}
+
+type EventRecorderOperator interface {
+	Event(object runtime.Object, eventtype, reason, message string)
+	Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any)
+}
+
+type DriftOperator interface {
+	Detect(ctx context.Context, owner, desired, live client.Object) (bool, error)
+	Pop(key types.NamespacedName) ([]string, metav1.Time, bool)
 }
 
 type LogOperator interface {
@@ -23,7 +42,7 @@ type LogOperator interface {
 }
 
 type ReconcileOperator interface {
-	Reconcile(ctx context.Context, req reconcile.Request, r APIClientOperator) error
+	Reconcile(ctx context.Context, scope *Scope, r APIClientOperator) error
 }
 
 type DeploymentOperator interface {
@@ -35,7 +54,7 @@ type ServiceOperator interface {
 }
 
 type NamespaceOperator interface {
-	ReconcileOperator
+	Reconcile(ctx context.Context, req reconcile.Request, r APIClientOperator) error
 }
 
 type DeploymentReferenceController interface {
@@ -43,5 +62,5 @@ type DeploymentReferenceController interface {
 }
 
 type ServiceReferenceController interface {
-	IsControlledBy(obj metav1.Object, owner metav1.Object) bool
+	SetControllerReference(owner, controlled metav1.Object, scheme *runtime.Scheme) error
 }
@@ -0,0 +1,158 @@
+// Package registry runs the sub-reconcilers of a dataLogger in the order of their dependencies
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/metrics"
+)
+
+// Step is a sub-reconciler registered under a name, it runs after the steps it depends on
+type Step struct {
+	Name      string
+	Operator  pkg.ReconcileOperator
+	DependsOn []string
+}
+
+// Outcome is the result of a step in a run. A step that was not run, as one of its
+// dependencies failed, has the name of that dependency in BlockedBy.
+type Outcome struct {
+	Name      string
+	Err       error
+	BlockedBy string
+}
+
+type Registry struct {
+	steps []Step
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds the operator under the name, to be run after the steps it depends on
+func (r *Registry) Register(name string, operator pkg.ReconcileOperator, dependsOn ...string) error {
+	if name == "" {
+		return fmt.Errorf("a step needs a name")
+	}
+
+	for _, step := range r.steps {
+		if step.Name == name {
+			return fmt.Errorf("step %s is already registered", name)
+		}
+	}
+
+	r.steps = append(r.steps, Step{Name: name, Operator: operator, DependsOn: dependsOn})
+
+	return nil
+}
+
+// Order returns the steps sorted so that every step comes after its dependencies. Steps that
+// do not depend on each other keep the order they were registered in.
+func (r *Registry) Order() ([]Step, error) {
+	registered := make(map[string]bool, len(r.steps))
+	for _, step := range r.steps {
+		registered[step.Name] = true
+	}
+
+	for _, step := range r.steps {
+		for _, dependency := range step.DependsOn {
+			if !registered[dependency] {
+				return nil, fmt.Errorf("step %s depends on %s, which is not registered", step.Name, dependency)
+			}
+		}
+	}
+
+	ordered := make([]Step, 0, len(r.steps))
+	done := make(map[string]bool, len(r.steps))
+
+	for len(ordered) < len(r.steps) {
+		progressed := false
+
+		for _, step := range r.steps {
+			if done[step.Name] || !dependenciesDone(step, done) {
+				continue
+			}
+
+			ordered = append(ordered, step)
+			done[step.Name] = true
+			progressed = true
+
+			// Start over, so that an earlier registered step runs as soon as it can
+			break
+		}
+
+		if !progressed {
+			return nil, fmt.Errorf("the dependencies of the steps contain a cycle")
+		}
+	}
+
+	return ordered, nil
+}
+
+// Run runs the steps in the order of their dependencies. A failed step blocks the steps that depend
+// on it, the other steps still run. It returns the outcomes in the order the steps were run and the
+// error of the first failed step.
+func (r *Registry) Run(ctx context.Context, scope *pkg.Scope, apiClient pkg.APIClientOperator) ([]Outcome, error) {
+	steps, err := r.Order()
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make([]Outcome, 0, len(steps))
+	failed := make(map[string]string, len(steps))
+
+	var firstErr error
+
+	for _, step := range steps {
+		if blockedBy := blocking(step, failed); blockedBy != "" {
+			failed[step.Name] = blockedBy
+			outcomes = append(outcomes, Outcome{Name: step.Name, BlockedBy: blockedBy})
+
+			continue
+		}
+
+		start := time.Now()
+
+		err := step.Operator.Reconcile(ctx, scope, apiClient)
+		metrics.ObserveReconcile(step.Name, start, err)
+
+		outcomes = append(outcomes, Outcome{Name: step.Name, Err: err})
+
+		if err != nil {
+			failed[step.Name] = step.Name
+
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return outcomes, firstErr
+}
+
+// dependenciesDone returns whether all dependencies of the step are ordered
+func dependenciesDone(step Step, done map[string]bool) bool {
+	for _, dependency := range step.DependsOn {
+		if !done[dependency] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// blocking returns the failed step that keeps the step from running, or an empty string.
+// failed maps the steps that failed or were blocked to the failed step at the root.
+func blocking(step Step, failed map[string]string) string {
+	for _, dependency := range step.DependsOn {
+		if root, ok := failed[dependency]; ok {
+			return root
+		}
+	}
+
+	return ""
+}
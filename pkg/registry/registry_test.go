@@ -0,0 +1,212 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg"
+)
+
+// registration is a step registered in a test
+type registration struct {
+	name      string
+	dependsOn []string
+}
+
+func TestRegistryOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		registrations []registration
+		order         []string
+		err           string
+	}{
+		{
+			name: "registration-order",
+			registrations: []registration{
+				{name: "deployment"},
+				{name: "service", dependsOn: []string{"deployment"}},
+			},
+			order: []string{"deployment", "service"},
+		},
+		{
+			name: "dependency-registered-later",
+			registrations: []registration{
+				{name: "monitor", dependsOn: []string{"service"}},
+				{name: "configmap"},
+				{name: "deployment", dependsOn: []string{"configmap"}},
+				{name: "service", dependsOn: []string{"deployment"}},
+			},
+			order: []string{"configmap", "deployment", "service", "monitor"},
+		},
+		{
+			name: "unknown-dependency",
+			registrations: []registration{
+				{name: "service", dependsOn: []string{"deployment"}},
+			},
+			err: "step service depends on deployment, which is not registered",
+		},
+		{
+			name: "cycle",
+			registrations: []registration{
+				{name: "deployment", dependsOn: []string{"service"}},
+				{name: "service", dependsOn: []string{"deployment"}},
+			},
+			err: "the dependencies of the steps contain a cycle",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			registry := NewRegistry()
+
+			for _, registration := range test.registrations {
+				require.Nil(t, registry.Register(registration.name, nil, registration.dependsOn...))
+			}
+
+			steps, err := registry.Order()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.Nil(t, err)
+
+			names := make([]string, 0, len(steps))
+			for _, step := range steps {
+				names = append(names, step.Name)
+			}
+
+			require.EqualValues(t, test.order, names)
+		})
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	registry := NewRegistry()
+
+	require.Nil(t, registry.Register("deployment", nil))
+	require.EqualError(t, registry.Register("deployment", nil), "step deployment is already registered")
+	require.EqualError(t, registry.Register("", nil), "a step needs a name")
+}
+
+func TestRegistryRun(t *testing.T) {
+	ctx := context.Background()
+
+	deploymentErr := errors.New("quota exceeded")
+
+	tests := []struct {
+		name     string
+		outcomes []Outcome
+		err      error
+	}{
+		{
+			name: "succeeded",
+			outcomes: []Outcome{
+				{Name: "configmap"},
+				{Name: "deployment"},
+				{Name: "service"},
+				{Name: "monitor"},
+			},
+		},
+		{
+			name: "deployment-failed",
+			outcomes: []Outcome{
+				{Name: "configmap"},
+				{Name: "deployment", Err: deploymentErr},
+				{Name: "service", BlockedBy: "deployment"},
+				{Name: "monitor", BlockedBy: "deployment"},
+			},
+			err: deploymentErr,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+			scope := pkg.NewScope(&appv1.DataLogger{})
+
+			registry := NewRegistry()
+
+			for _, registration := range []registration{
+				{name: "configmap"},
+				{name: "deployment", dependsOn: []string{"configmap"}},
+				{name: "service", dependsOn: []string{"deployment"}},
+				{name: "monitor", dependsOn: []string{"service", "configmap"}},
+			} {
+				operator := pkg.NewMockReconcileOperator(mockCtrl)
+
+				for _, outcome := range test.outcomes {
+					if outcome.Name == registration.name && outcome.BlockedBy == "" {
+						operator.EXPECT().Reconcile(ctx, scope, apiClient).Times(1).Return(outcome.Err)
+					}
+				}
+
+				require.Nil(t, registry.Register(registration.name, operator, registration.dependsOn...))
+			}
+
+			outcomes, err := registry.Run(ctx, scope, apiClient)
+			require.EqualValues(t, test.err, err)
+			require.EqualValues(t, test.outcomes, outcomes)
+		})
+	}
+}
+
+func TestRegistryRunSharesScope(t *testing.T) {
+	ctx := context.Background()
+
+	mockCtrl := gomock.NewController(t)
+	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
+
+	dataLogger := &appv1.DataLogger{}
+	dataLogger.ObjectMeta.Name = "datalogger-sample"
+	dataLogger.ObjectMeta.Namespace = "my-namespace1"
+
+	scope := pkg.NewScope(dataLogger)
+	configMap := &corev1.ConfigMap{}
+
+	configmap := pkg.NewMockReconcileOperator(mockCtrl)
+	configmap.EXPECT().Reconcile(ctx, scope, apiClient).Times(1).DoAndReturn(
+		func(ctx context.Context, scope *pkg.Scope, r pkg.APIClientOperator) error {
+			scope.SetChild("ConfigMap", configMap)
+			return nil
+		},
+	)
+
+	deployment := pkg.NewMockReconcileOperator(mockCtrl)
+	deployment.EXPECT().Reconcile(ctx, scope, apiClient).Times(1).DoAndReturn(
+		func(ctx context.Context, scope *pkg.Scope, r pkg.APIClientOperator) error {
+			require.EqualValues(t, types.NamespacedName{Name: "datalogger-sample", Namespace: "my-namespace1"}, client.ObjectKeyFromObject(scope.DataLogger))
+
+			child, ok := scope.Child("ConfigMap")
+			require.True(t, ok)
+			require.Same(t, configMap, child)
+
+			_, ok = scope.Child("Service")
+			require.False(t, ok)
+
+			return nil
+		},
+	)
+
+	registry := NewRegistry()
+	require.Nil(t, registry.Register("deployment", deployment, "configmap"))
+	require.Nil(t, registry.Register("configmap", configmap))
+
+	_, err := registry.Run(ctx, scope, apiClient)
+	require.Nil(t, err)
+}
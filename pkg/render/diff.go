@@ -0,0 +1,141 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/drift"
+	"stackit.cloud/datalogger/pkg/utils/apply"
+)
+
+// Difference is the difference of a rendered object to the object in the cluster
+type Difference struct {
+	Object  client.Object
+	Missing bool
+	Paths   []string
+	Patch   []byte
+}
+
+// Drifted returns whether the object in the cluster is missing or differs from the rendered one
+func (d Difference) Drifted() bool {
+	return d.Missing || len(d.Paths) > 0
+}
+
+// Diff renders the objects and compares each rendered object with the one in the cluster. Only the
+// fields the operator sets are compared, so server defaults and fields of others are ignored.
+func (r *Renderer) Diff(ctx context.Context, apiClient pkg.APIClientOperator, objs []client.Object) ([]Difference, error) {
+	differences := []Difference{}
+
+	for _, obj := range objs {
+		// The children are owned by the dataLogger in the cluster, which the file does not know the UID of
+		if source, ok := obj.(*appv1.DataLogger); ok {
+			live := &appv1.DataLogger{}
+
+			err := apiClient.Get(ctx, client.ObjectKeyFromObject(source), live)
+			if client.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+
+			source = source.DeepCopy()
+			source.UID = live.UID
+			obj = source
+		}
+
+		rendered, err := r.Render([]client.Object{obj})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, desired := range rendered {
+			difference, err := compare(ctx, apiClient, desired)
+			if err != nil {
+				return nil, err
+			}
+
+			differences = append(differences, difference)
+		}
+	}
+
+	return differences, nil
+}
+
+// compare fetches the object in the cluster and compares it with desired
+func compare(ctx context.Context, apiClient pkg.APIClientOperator, desired client.Object) (Difference, error) {
+	gvk := desired.GetObjectKind().GroupVersionKind()
+
+	obj, err := Scheme.New(gvk)
+	if err != nil {
+		return Difference{}, err
+	}
+
+	live := obj.(client.Object)
+
+	err = apiClient.Get(ctx, client.ObjectKeyFromObject(desired), live)
+	if errors.IsNotFound(err) {
+		return Difference{Object: desired, Missing: true}, nil
+	}
+
+	if err != nil {
+		return Difference{}, err
+	}
+
+	// The operator leaves the replicas to an autoscaler, once it scales the deployment
+	if deployment, ok := desired.(*appsv1.Deployment); ok && apply.ManagedThrough(live, "scale", "spec", "replicas") {
+		deployment.Spec.Replicas = nil
+	}
+
+	paths, patch, err := drift.Paths(desired, live)
+	if err != nil {
+		return Difference{}, err
+	}
+
+	if len(paths) == 0 {
+		return Difference{Object: desired}, nil
+	}
+
+	return Difference{Object: desired, Paths: paths, Patch: patch}, nil
+}
+
+// WriteDiff writes for each difference whether the object is in sync, missing or drifted.
+// The JSON patch of a drifted object leads from the rendered to the live object.
+func WriteDiff(writer io.Writer, differences []Difference) error {
+	buffer := &bytes.Buffer{}
+
+	for _, difference := range differences {
+		obj := difference.Object
+		name := obj.GetName()
+
+		if obj.GetNamespace() != "" {
+			name = obj.GetNamespace() + "/" + name
+		}
+
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+
+		switch {
+		case difference.Missing:
+			fmt.Fprintf(buffer, "%s %s is missing\n", kind, name)
+		case len(difference.Paths) > 0:
+			fmt.Fprintf(buffer, "%s %s drifted:\n", kind, name)
+
+			if err := json.Indent(buffer, difference.Patch, "", "  "); err != nil {
+				return err
+			}
+
+			buffer.WriteString("\n")
+		default:
+			fmt.Fprintf(buffer, "%s %s is in sync\n", kind, name)
+		}
+	}
+
+	_, err := writer.Write(buffer.Bytes())
+
+	return err
+}
@@ -0,0 +1,149 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg/deployment"
+)
+
+const dataLoggerManifest = `
+apiVersion: app.stackit.cloud/v1
+kind: DataLogger
+metadata:
+  name: datalogger-sample-42
+  namespace: my-namespace1
+spec:
+  custom-name: datalogger-42
+  replicas: 1
+  port: 8080
+  target-port: 80
+  node-port: 32101
+`
+
+// liveChildren renders the children of the manifest as the cluster returns them, owned by the
+// dataLogger with the UID and with the fields the API server defaults
+func liveChildren(t *testing.T, uid types.UID) (*appsv1.Deployment, *corev1.Service) {
+	objs, err := Decode(strings.NewReader(dataLoggerManifest))
+	require.Nil(t, err)
+
+	dataLogger := objs[0].(*appv1.DataLogger)
+	dataLogger.UID = uid
+
+	rendered, err := NewRenderer(deployment.Defaults{}, appv1.DataLoggerDefaulter{}).DataLogger(dataLogger)
+	require.Nil(t, err)
+
+	live := rendered[0].(*appsv1.Deployment)
+	live.Annotations = map[string]string{"app.stackit.cloud/last-applied-hash": "0123"}
+	live.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+	live.Spec.Template.Spec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	live.Spec.Template.Spec.Containers[0].Ports[0].Protocol = corev1.ProtocolTCP
+
+	service := rendered[1].(*corev1.Service)
+	service.Spec.ClusterIP = "10.96.0.12"
+	service.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+
+	return live, service
+}
+
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		mutate  func(dataLogger *appv1.DataLogger, deployment *appsv1.Deployment, service *corev1.Service) []client.Object
+		output  string
+		drifted bool
+	}{
+		{
+			name: "in-sync",
+			mutate: func(dataLogger *appv1.DataLogger, deployment *appsv1.Deployment, service *corev1.Service) []client.Object {
+				return []client.Object{dataLogger, deployment, service}
+			},
+			output: "Deployment my-namespace1/datalogger-42 is in sync\n" +
+				"Service my-namespace1/datalogger-42 is in sync\n",
+		},
+		{
+			name: "drifted",
+			mutate: func(dataLogger *appv1.DataLogger, deployment *appsv1.Deployment, service *corev1.Service) []client.Object {
+				deployment.Spec.Replicas = ptr.To(int32(5))
+				return []client.Object{dataLogger, deployment, service}
+			},
+			output: "Deployment my-namespace1/datalogger-42 drifted:\n" +
+				"[\n  {\n    \"value\": 5,\n    \"op\": \"replace\",\n    \"path\": \"/spec/replicas\"\n  }\n]\n" +
+				"Service my-namespace1/datalogger-42 is in sync\n",
+			drifted: true,
+		},
+		{
+			name: "autoscaled",
+			mutate: func(dataLogger *appv1.DataLogger, deployment *appsv1.Deployment, service *corev1.Service) []client.Object {
+				deployment.Spec.Replicas = ptr.To(int32(5))
+				deployment.ManagedFields = []metav1.ManagedFieldsEntry{{
+					Manager:     "hpa",
+					Operation:   metav1.ManagedFieldsOperationUpdate,
+					Subresource: "scale",
+					FieldsType:  "FieldsV1",
+					FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+				}}
+				return []client.Object{dataLogger, deployment, service}
+			},
+			output: "Deployment my-namespace1/datalogger-42 is in sync\n" +
+				"Service my-namespace1/datalogger-42 is in sync\n",
+		},
+		{
+			name: "missing",
+			mutate: func(dataLogger *appv1.DataLogger, deployment *appsv1.Deployment, service *corev1.Service) []client.Object {
+				return []client.Object{dataLogger, deployment}
+			},
+			output: "Deployment my-namespace1/datalogger-42 is in sync\n" +
+				"Service my-namespace1/datalogger-42 is missing\n",
+			drifted: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			objs, err := Decode(strings.NewReader(dataLoggerManifest))
+			require.Nil(t, err)
+
+			dataLogger := objs[0].DeepCopyObject().(*appv1.DataLogger)
+			dataLogger.UID = "datalogger-uid"
+
+			liveDeployment, liveService := liveChildren(t, dataLogger.UID)
+
+			apiClient := fake.NewClientBuilder().
+				WithScheme(Scheme).
+				WithObjects(test.mutate(dataLogger, liveDeployment, liveService)...).
+				Build()
+
+			differences, err := NewRenderer(deployment.Defaults{}, appv1.DataLoggerDefaulter{}).Diff(ctx, apiClient, objs)
+			require.Nil(t, err)
+
+			drifted := false
+			for _, difference := range differences {
+				drifted = drifted || difference.Drifted()
+			}
+
+			require.EqualValues(t, test.drifted, drifted)
+
+			buffer := &bytes.Buffer{}
+			require.Nil(t, WriteDiff(buffer, differences))
+			require.EqualValues(t, test.output, buffer.String())
+		})
+	}
+}
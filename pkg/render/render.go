@@ -0,0 +1,199 @@
+// Package render renders the objects the operator creates for DataLoggers without contacting a cluster
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	appv2 "stackit.cloud/datalogger/api/v2"
+	"stackit.cloud/datalogger/internal"
+	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/deployment"
+	"stackit.cloud/datalogger/pkg/namespace"
+	"stackit.cloud/datalogger/pkg/service"
+)
+
+// Scheme knows the kinds that are read and rendered
+var Scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(Scheme))
+	utilruntime.Must(appv1.AddToScheme(Scheme))
+	utilruntime.Must(appv2.AddToScheme(Scheme))
+}
+
+// Renderer renders the children of dataLoggers as the sub-reconcilers of the operator create them
+type Renderer struct {
+	defaulter           appv1.DataLoggerDefaulter
+	deployment          *deployment.Deployment
+	service             *service.Service
+	deploymentReference pkg.DeploymentReferenceController
+	serviceReference    pkg.ServiceReferenceController
+}
+
+// NewRenderer returns a renderer using the same defaults as an operator started with them
+func NewRenderer(defaults deployment.Defaults, defaulter appv1.DataLoggerDefaulter) *Renderer {
+	return &Renderer{
+		defaulter:           defaulter,
+		deployment:          deployment.NewDeployment(nil, nil, nil, defaults),
+		service:             service.NewService(nil, nil, nil),
+		deploymentReference: internal.NewDeploymentReference(),
+		serviceReference:    internal.NewServiceReference(),
+	}
+}
+
+// Render returns the objects the operator creates for the DataLoggers and namespaces in objs,
+// in the order they were passed
+func (r *Renderer) Render(objs []client.Object) ([]client.Object, error) {
+	rendered := []client.Object{}
+
+	for _, obj := range objs {
+		switch source := obj.(type) {
+		case *appv1.DataLogger:
+			children, err := r.DataLogger(source)
+			if err != nil {
+				return nil, err
+			}
+
+			rendered = append(rendered, children...)
+		case *corev1.Namespace:
+			rendered = append(rendered, Namespaces(source)...)
+		default:
+			return nil, fmt.Errorf("unable to render %s %s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())
+		}
+	}
+
+	return rendered, nil
+}
+
+// DataLogger returns the Deployment and the Service of the dataLogger. The spec is defaulted
+// first, as the defaulting webhook does before the operator sees the dataLogger.
+func (r *Renderer) DataLogger(source *appv1.DataLogger) ([]client.Object, error) {
+	dataLogger := source.DeepCopy()
+	r.defaulter.SetDefaults(&dataLogger.Spec)
+
+	if errs := appv1.ValidateSpec(&dataLogger.Spec, field.NewPath("spec")); len(errs) > 0 {
+		return nil, fmt.Errorf("dataLogger %s is invalid: %w", dataLogger.Name, errs.ToAggregate())
+	}
+
+	deployment := r.deployment.CreateDeployment(dataLogger)
+	if err := r.deploymentReference.SetControllerReference(dataLogger, deployment, Scheme); err != nil {
+		return nil, err
+	}
+
+	service := r.service.NewServiceForDataLogger(dataLogger)
+	if err := r.serviceReference.SetControllerReference(dataLogger, service, Scheme); err != nil {
+		return nil, err
+	}
+
+	return []client.Object{deployment, service}, nil
+}
+
+// Namespaces returns the namespaces listed in the labels of the source namespace, sorted by label
+func Namespaces(source *corev1.Namespace) []client.Object {
+	keys := make([]string, 0, len(source.Labels))
+	for key := range source.Labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	namespaces := []client.Object{}
+
+	for _, key := range keys {
+		if listed, ok := namespace.ListedNamespace(key, source.Labels[key]); ok {
+			namespaces = append(namespaces, listed)
+		}
+	}
+
+	return namespaces
+}
+
+// Decode reads the YAML or JSON documents of the reader. v2 DataLoggers are converted to v1,
+// which is the version the operator reconciles.
+func Decode(reader io.Reader) ([]client.Object, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, 4096)
+	objs := []client.Object{}
+
+	for {
+		document := &unstructured.Unstructured{}
+
+		err := decoder.Decode(document)
+		if err == io.EOF {
+			return objs, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(document.Object) == 0 {
+			continue
+		}
+
+		obj, err := Scheme.New(document.GroupVersionKind())
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s %s: unknown kind %s", document.GetKind(), document.GetName(), document.GetAPIVersion())
+		}
+
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(document.Object, obj); err != nil {
+			return nil, fmt.Errorf("unable to read %s %s: %w", document.GetKind(), document.GetName(), err)
+		}
+
+		if hub, ok := obj.(*appv2.DataLogger); ok {
+			dataLogger := &appv1.DataLogger{}
+			if err = dataLogger.ConvertFrom(hub); err != nil {
+				return nil, err
+			}
+
+			dataLogger.SetGroupVersionKind(appv1.GroupVersion.WithKind("DataLogger"))
+			obj = dataLogger
+		}
+
+		objs = append(objs, obj.(client.Object))
+	}
+}
+
+// Write writes the objects as YAML documents. Fields only set by the cluster, as the status
+// and the creation timestamps, are left out.
+func Write(writer io.Writer, objs []client.Object) error {
+	buffer := &bytes.Buffer{}
+
+	for i, obj := range objs {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+
+		unstructured.RemoveNestedField(content, "status")
+		unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(content, "spec", "template", "metadata", "creationTimestamp")
+
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			buffer.WriteString("---\n")
+		}
+
+		buffer.Write(data)
+	}
+
+	_, err := writer.Write(buffer.Bytes())
+
+	return err
+}
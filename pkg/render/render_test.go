@@ -0,0 +1,176 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg/deployment"
+)
+
+const manifests = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: cr-default-ns
+  labels:
+    namespaces2: my-namespace2
+    namespaces1: my-namespace1
+    name: cr-default-ns
+---
+apiVersion: app.stackit.cloud/v1
+kind: DataLogger
+metadata:
+  name: datalogger-sample-42
+  namespace: my-namespace1
+spec:
+  custom-name: datalogger-42
+  port: 8080
+  target-port: 80
+  node-port: 32101
+---
+apiVersion: app.stackit.cloud/v2
+kind: DataLogger
+metadata:
+  name: datalogger-sample-43
+  namespace: my-namespace2
+spec:
+  customName: datalogger-43
+  workload:
+    replicas: 2
+    image: nginx
+`
+
+func TestRender(t *testing.T) {
+	objs, err := Decode(strings.NewReader(manifests))
+	require.Nil(t, err)
+	require.Len(t, objs, 3)
+
+	renderer := NewRenderer(deployment.Defaults{}, appv1.DataLoggerDefaulter{Replicas: 3, ServiceType: corev1.ServiceTypeClusterIP})
+
+	rendered, err := renderer.Render(objs)
+	require.Nil(t, err)
+
+	names := make([]string, 0, len(rendered))
+	for _, obj := range rendered {
+		names = append(names, fmt.Sprintf("%s %s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName()))
+	}
+
+	require.EqualValues(t, []string{
+		"Namespace /my-namespace1",
+		"Namespace /my-namespace2",
+		"Deployment my-namespace1/datalogger-42",
+		"Service my-namespace1/datalogger-42",
+		"Deployment my-namespace2/datalogger-43",
+		"Service my-namespace2/datalogger-43",
+	}, names)
+
+	// The spec is defaulted as by the webhook, with the defaults of the renderer
+	first := rendered[2].(*appsv1.Deployment)
+	require.EqualValues(t, 3, *first.Spec.Replicas)
+	require.EqualValues(t, deployment.DefaultImage, first.Spec.Template.Spec.Containers[0].Image)
+	require.EqualValues(t, corev1.ServiceTypeNodePort, rendered[3].(*corev1.Service).Spec.Type)
+
+	second := rendered[4].(*appsv1.Deployment)
+	require.EqualValues(t, 2, *second.Spec.Replicas)
+	require.EqualValues(t, "nginx", second.Spec.Template.Spec.Containers[0].Image)
+	require.EqualValues(t, corev1.ServiceTypeClusterIP, rendered[5].(*corev1.Service).Spec.Type)
+
+	for _, obj := range rendered[2:] {
+		owner := metav1.GetControllerOf(obj)
+		require.NotNil(t, owner)
+		require.EqualValues(t, "DataLogger", owner.Kind)
+		require.EqualValues(t, appv1.GroupVersion.String(), owner.APIVersion)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifests string
+		err       string
+	}{
+		{
+			name: "invalid",
+			manifests: `
+apiVersion: app.stackit.cloud/v1
+kind: DataLogger
+metadata:
+  name: datalogger-sample
+spec:
+  replicas: 1
+`,
+			err: "dataLogger datalogger-sample is invalid: spec.customName: Required value: must be set",
+		},
+		{
+			name: "unsupported-kind",
+			manifests: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`,
+			err: "unable to render ConfigMap settings",
+		},
+		{
+			name: "unknown-kind",
+			manifests: `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+`,
+			err: "unable to read Widget widget: unknown kind example.com/v1",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			objs, err := Decode(strings.NewReader(test.manifests))
+			if err == nil {
+				_, err = NewRenderer(deployment.Defaults{}, appv1.DataLoggerDefaulter{}).Render(objs)
+			}
+
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	objs := []client.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-namespace1"},
+		},
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-namespace2"},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+	require.Nil(t, Write(buffer, objs))
+	require.EqualValues(t, `apiVersion: v1
+kind: Namespace
+metadata:
+  name: my-namespace1
+spec: {}
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: my-namespace2
+spec: {}
+`, buffer.String())
+}
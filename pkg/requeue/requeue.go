@@ -0,0 +1,188 @@
+// Package requeue classifies reconcile errors and decides when a failed request is retried
+package requeue
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Class tells how a reconcile error is retried
+type Class string
+
+// Classes of reconcile errors
+const (
+	// ClassTransient errors are retried with a per-object exponential backoff
+	ClassTransient Class = "Transient"
+	// ClassConflict errors are retried after the base delay without backing off, as the next attempt
+	// reads the latest version
+	ClassConflict Class = "Conflict"
+	// ClassTerminal errors are not retried until the object changes, e.g. an invalid spec
+	ClassTerminal Class = "Terminal"
+	// ClassWaiting errors are retried after a delay, as another object has to appear first
+	ClassWaiting Class = "Waiting"
+)
+
+// Error is a reconcile error of a given class
+type Error struct {
+	Class Class
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Transient marks err as transient
+func Transient(err error) error {
+	return wrap(ClassTransient, err)
+}
+
+// Conflict marks err as a conflict
+func Conflict(err error) error {
+	return wrap(ClassConflict, err)
+}
+
+// Terminal marks err as terminal
+func Terminal(err error) error {
+	return wrap(ClassTerminal, err)
+}
+
+// Waiting marks err as waiting on a dependency
+func Waiting(err error) error {
+	return wrap(ClassWaiting, err)
+}
+
+func wrap(class Class, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{Class: class, Err: err}
+}
+
+// ClassOf returns the class of err. Errors that were not marked are classified by their API status:
+// conflicts, invalid or bad requests (terminal) and missing objects (waiting). Everything else is transient.
+func ClassOf(err error) Class {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.Class
+	}
+
+	switch {
+	case apierrors.IsConflict(err):
+		return ClassConflict
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return ClassTerminal
+	case apierrors.IsNotFound(err):
+		return ClassWaiting
+	default:
+		return ClassTransient
+	}
+}
+
+// Options configures the backoff of failed requests
+type Options struct {
+	// BaseDelay is the delay before the first retry of a transient error and before every retry of a conflict
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff
+	MaxDelay time.Duration
+	// WaitDelay is the delay before a request waiting on a dependency is retried
+	WaitDelay time.Duration
+	// Jitter is the fraction of a delay that is randomized, e.g. 0.1 for +-10%
+	Jitter float64
+}
+
+// DefaultOptions returns the backoff used when no flags are set
+func DefaultOptions() Options {
+	return Options{
+		BaseDelay: 5 * time.Millisecond,
+		MaxDelay:  5 * time.Minute,
+		WaitDelay: 10 * time.Second,
+		Jitter:    0.1,
+	}
+}
+
+// Result maps the error of a reconcile to the result returned to controller-runtime.
+// Transient errors are returned as is, so the rate limiter backs off; conflicts are requeued after
+// the base delay, terminal errors are not requeued and waiting errors are requeued after the
+// jittered wait delay. A RequeueAfter resets the backoff of the object instead of counting a failure.
+func (o Options) Result(err error) (ctrl.Result, error) {
+	if err == nil {
+		return ctrl.Result{}, nil
+	}
+
+	switch ClassOf(err) {
+	case ClassConflict:
+		return ctrl.Result{RequeueAfter: o.BaseDelay}, nil
+	case ClassTerminal:
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	case ClassWaiting:
+		return ctrl.Result{RequeueAfter: jitter(o.WaitDelay, o.Jitter, rand.Float64())}, nil
+	default:
+		return ctrl.Result{}, err
+	}
+}
+
+// RateLimiter backs off the requests of each object exponentially with jitter
+type RateLimiter struct {
+	options  Options
+	random   func() float64
+	mu       sync.Mutex
+	failures map[any]int
+}
+
+// NewRateLimiter returns a rate limiter for the controller options
+func NewRateLimiter(options Options) *RateLimiter {
+	return &RateLimiter{options: options, random: rand.Float64, failures: map[any]int{}}
+}
+
+// When returns the delay before the item is retried and counts the failure
+func (r *RateLimiter) When(item any) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	failures := r.failures[item]
+	r.failures[item] = failures + 1
+
+	delay := r.options.BaseDelay
+	for i := 0; i < failures && delay < r.options.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > r.options.MaxDelay {
+		delay = r.options.MaxDelay
+	}
+
+	return jitter(delay, r.options.Jitter, r.random())
+}
+
+// Forget resets the backoff of the item
+func (r *RateLimiter) Forget(item any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.failures, item)
+}
+
+// NumRequeues returns the failures of the item since it was last forgotten
+func (r *RateLimiter) NumRequeues(item any) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.failures[item]
+}
+
+// jitter spreads delay by +-fraction, random is in [0, 1)
+func jitter(delay time.Duration, fraction float64, random float64) time.Duration {
+	return delay + time.Duration(float64(delay)*fraction*(2*random-1))
+}
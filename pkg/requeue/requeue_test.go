@@ -0,0 +1,108 @@
+package requeue
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassOf(t *testing.T) {
+	groupResource := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	tests := []struct {
+		name string
+		err  error
+		want Class
+	}{
+		{
+			name: "unknown",
+			err:  errors.New("connection refused"),
+			want: ClassTransient,
+		},
+		{
+			name: "conflict",
+			err:  apierrors.NewConflict(groupResource, "datalogger-42", errors.New("object was modified")),
+			want: ClassConflict,
+		},
+		{
+			name: "invalid",
+			err:  apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "datalogger-42", nil),
+			want: ClassTerminal,
+		},
+		{
+			name: "not-found",
+			err:  apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "my-namespace1"),
+			want: ClassWaiting,
+		},
+		{
+			name: "marked",
+			err:  errors.Wrap(Terminal(errors.New("owned by another controller")), "unable to set owner"),
+			want: ClassTerminal,
+		},
+		{
+			name: "marked-api-error",
+			err:  Transient(apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "my-namespace1")),
+			want: ClassTransient,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			require.EqualValues(t, test.want, ClassOf(test.err))
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(Options{BaseDelay: time.Second, MaxDelay: 5 * time.Second})
+
+	require.EqualValues(t, time.Second, limiter.When("datalogger-42"))
+	require.EqualValues(t, 2*time.Second, limiter.When("datalogger-42"))
+	require.EqualValues(t, 4*time.Second, limiter.When("datalogger-42"))
+	require.EqualValues(t, 5*time.Second, limiter.When("datalogger-42"))
+	require.EqualValues(t, 4, limiter.NumRequeues("datalogger-42"))
+
+	// Other objects back off on their own
+	require.EqualValues(t, time.Second, limiter.When("datalogger-43"))
+
+	limiter.Forget("datalogger-42")
+	require.EqualValues(t, 0, limiter.NumRequeues("datalogger-42"))
+	require.EqualValues(t, time.Second, limiter.When("datalogger-42"))
+}
+
+func TestResultConflictDelay(t *testing.T) {
+	options := Options{BaseDelay: time.Second, MaxDelay: 5 * time.Second, WaitDelay: 10 * time.Second}
+	limiter := NewRateLimiter(options)
+
+	// The object already failed twice, so a rate limited retry would wait 4s
+	limiter.When("datalogger-42")
+	limiter.When("datalogger-42")
+
+	conflict := apierrors.NewConflict(
+		schema.GroupResource{Group: "apps", Resource: "deployments"}, "datalogger-42", errors.New("object was modified"),
+	)
+
+	// controller-runtime only asks the rate limiter for results with Requeue set and no RequeueAfter
+	for i := 0; i < 3; i++ {
+		result, err := options.Result(conflict)
+		require.Nil(t, err)
+		require.False(t, result.Requeue)
+		require.EqualValues(t, time.Second, result.RequeueAfter)
+	}
+
+	require.EqualValues(t, 4*time.Second, limiter.When("datalogger-42"))
+}
+
+func TestJitter(t *testing.T) {
+	require.EqualValues(t, 9*time.Second, jitter(10*time.Second, 0.1, 0))
+	require.EqualValues(t, 10*time.Second, jitter(10*time.Second, 0.1, 0.5))
+	require.EqualValues(t, 10*time.Second, jitter(10*time.Second, 0, 0.9))
+}
@@ -0,0 +1,30 @@
+package pkg
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	appv1 "stackit.cloud/datalogger/api/v1"
+)
+
+// Scope is the state of a single reconcile of a dataLogger, shared by its sub-reconcilers.
+// The dataLogger is fetched once, so that all sub-reconcilers see the same version of the spec.
+type Scope struct {
+	DataLogger *appv1.DataLogger
+
+	children map[string]client.Object
+}
+
+func NewScope(dataLogger *appv1.DataLogger) *Scope {
+	return &Scope{DataLogger: dataLogger, children: map[string]client.Object{}}
+}
+
+// SetChild stores the child a sub-reconciler applied for the dataLogger under its kind,
+// as it is in the cluster after the apply
+func (s *Scope) SetChild(kind string, child client.Object) {
+	s.children[kind] = child
+}
+
+// Child returns the child of the kind, if a sub-reconciler applied one in this reconcile
+func (s *Scope) Child(kind string) (client.Object, bool) {
+	child, ok := s.children[kind]
+	return child, ok
+}
@@ -0,0 +1,173 @@
+// Package sdk provisions DataLoggers and waits for the operator to reconcile them,
+// for Go services and integration tests
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg"
+)
+
+// DefaultInterval is the time between two polls of WaitReady and Delete
+const DefaultInterval = time.Second
+
+type Client struct {
+	apiClient pkg.APIClientOperator
+	interval  time.Duration
+}
+
+func NewClient(apiClient pkg.APIClientOperator, interval time.Duration) *Client {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Client{apiClient: apiClient, interval: interval}
+}
+
+// Create creates the dataLogger. The operator adds its finalizer and the children afterwards, see WaitReady.
+func (c *Client) Create(ctx context.Context, dataLogger *appv1.DataLogger) error {
+	return c.apiClient.Create(ctx, dataLogger)
+}
+
+// Update applies mutate to the latest dataLogger and updates it, retrying with a fresh copy on conflicts.
+// A dataLogger that is being deleted is not updated, as the operator only finalizes it.
+func (c *Client) Update(ctx context.Context, key types.NamespacedName, mutate func(dataLogger *appv1.DataLogger)) (*appv1.DataLogger, error) {
+	dataLogger := &appv1.DataLogger{}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := c.apiClient.Get(ctx, key, dataLogger); err != nil {
+			return err
+		}
+
+		if !dataLogger.ObjectMeta.DeletionTimestamp.IsZero() {
+			return fmt.Errorf("dataLogger %s is being deleted", key)
+		}
+
+		mutate(dataLogger)
+
+		return c.apiClient.Update(ctx, dataLogger)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dataLogger, nil
+}
+
+// Delete deletes the dataLogger and waits until the operator finalized it and it is gone. It fails early
+// if the operator reports the finalization as stuck, and with the child it waits for after the timeout.
+func (c *Client) Delete(ctx context.Context, key types.NamespacedName, timeout time.Duration) error {
+	dataLogger := &appv1.DataLogger{}
+	if err := c.apiClient.Get(ctx, key, dataLogger); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if err := c.apiClient.Delete(ctx, dataLogger); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, c.interval, timeout, true, func(ctx context.Context) (bool, error) {
+		err := c.apiClient.Get(ctx, key, dataLogger)
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		stuck := meta.FindStatusCondition(dataLogger.Status.Conditions, appv1.ConditionFinalizeStuck)
+		if stuck != nil && stuck.Status == metav1.ConditionTrue {
+			return false, fmt.Errorf("dataLogger %s is stuck finalizing: %s", key, stuck.Message)
+		}
+
+		return false, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("dataLogger %s is not deleted after %s: %s", key, timeout, finalizing(dataLogger))
+	}
+
+	return err
+}
+
+// WaitReady waits until the operator observed the latest generation of the dataLogger and reports it
+// as Ready, and returns it. A failed reconcile is retried by the operator, so it is only reported once
+// the timeout passed; a dataLogger that is being deleted or is gone fails right away.
+func (c *Client) WaitReady(ctx context.Context, key types.NamespacedName, timeout time.Duration) (*appv1.DataLogger, error) {
+	dataLogger := &appv1.DataLogger{}
+
+	err := wait.PollUntilContextTimeout(ctx, c.interval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.apiClient.Get(ctx, key, dataLogger); err != nil {
+			return false, err
+		}
+
+		if !dataLogger.ObjectMeta.DeletionTimestamp.IsZero() {
+			return false, fmt.Errorf("dataLogger %s is being deleted", key)
+		}
+
+		return notReady(dataLogger) == "", nil
+	})
+	if wait.Interrupted(err) {
+		return nil, fmt.Errorf("dataLogger %s is not ready after %s: %s", key, timeout, notReady(dataLogger))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dataLogger, nil
+}
+
+// Endpoint returns the in-cluster address of the service of the dataLogger, as reported in its status
+func (c *Client) Endpoint(ctx context.Context, key types.NamespacedName) (string, error) {
+	dataLogger := &appv1.DataLogger{}
+	if err := c.apiClient.Get(ctx, key, dataLogger); err != nil {
+		return "", err
+	}
+
+	if dataLogger.Status.Endpoint == "" {
+		return "", fmt.Errorf("dataLogger %s has no endpoint yet", key)
+	}
+
+	return dataLogger.Status.Endpoint, nil
+}
+
+// notReady returns why the dataLogger is not ready, or an empty string if it is
+func notReady(dataLogger *appv1.DataLogger) string {
+	if dataLogger.Status.ObservedGeneration < dataLogger.Generation {
+		return fmt.Sprintf("generation %d is not observed yet", dataLogger.Generation)
+	}
+
+	ready := meta.FindStatusCondition(dataLogger.Status.Conditions, appv1.ConditionReady)
+
+	switch {
+	case ready == nil:
+		return "no Ready condition yet"
+	case ready.ObservedGeneration < dataLogger.Generation:
+		return fmt.Sprintf("the Ready condition is of generation %d", ready.ObservedGeneration)
+	case ready.Status != metav1.ConditionTrue:
+		return fmt.Sprintf("%s: %s", ready.Reason, ready.Message)
+	default:
+		return ""
+	}
+}
+
+// finalizing returns what the finalization of the dataLogger waits for
+func finalizing(dataLogger *appv1.DataLogger) string {
+	condition := meta.FindStatusCondition(dataLogger.Status.Conditions, appv1.ConditionFinalizing)
+	if condition == nil {
+		return "the operator has not started finalizing it"
+	}
+
+	return condition.Message
+}
@@ -0,0 +1,238 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	appv1 "stackit.cloud/datalogger/api/v1"
+	"stackit.cloud/datalogger/pkg/render"
+)
+
+var key = types.NamespacedName{Name: "datalogger-sample-42", Namespace: "my-namespace1"}
+
+func newDataLogger(mutate func(dataLogger *appv1.DataLogger)) *appv1.DataLogger {
+	dataLogger := &appv1.DataLogger{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Generation: 2},
+		Spec:       appv1.DataLoggerSpec{CustomName: "datalogger-42", Replicas: 1, Port: 8080, TargetPort: 80},
+		Status: appv1.DataLoggerStatus{
+			ObservedGeneration: 2,
+			Endpoint:           "datalogger-42.my-namespace1.svc:8080",
+			Conditions: []metav1.Condition{{
+				Type: appv1.ConditionReady, Status: metav1.ConditionTrue, ObservedGeneration: 2,
+				Reason: "Available", Message: "all resources are available",
+			}},
+		},
+	}
+
+	if mutate != nil {
+		mutate(dataLogger)
+	}
+
+	return dataLogger
+}
+
+func newClient(objs ...client.Object) *Client {
+	apiClient := fake.NewClientBuilder().WithScheme(render.Scheme).WithObjects(objs...).Build()
+
+	return NewClient(apiClient, time.Millisecond)
+}
+
+func TestClientWaitReady(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		mutate func(dataLogger *appv1.DataLogger)
+		err    string
+	}{
+		{
+			name: "ready",
+		},
+		{
+			name: "not-observed",
+			mutate: func(dataLogger *appv1.DataLogger) {
+				dataLogger.Status.ObservedGeneration = 1
+			},
+			err: "dataLogger my-namespace1/datalogger-sample-42 is not ready after 20ms: generation 2 is not observed yet",
+		},
+		{
+			name: "stale-condition",
+			mutate: func(dataLogger *appv1.DataLogger) {
+				dataLogger.Status.Conditions[0].ObservedGeneration = 1
+			},
+			err: "dataLogger my-namespace1/datalogger-sample-42 is not ready after 20ms: the Ready condition is of generation 1",
+		},
+		{
+			name: "no-condition",
+			mutate: func(dataLogger *appv1.DataLogger) {
+				dataLogger.Status.Conditions = nil
+			},
+			err: "dataLogger my-namespace1/datalogger-sample-42 is not ready after 20ms: no Ready condition yet",
+		},
+		{
+			name: "failed",
+			mutate: func(dataLogger *appv1.DataLogger) {
+				dataLogger.Status.Conditions[0].Status = metav1.ConditionFalse
+				dataLogger.Status.Conditions[0].Reason = "ReconcileFailed"
+				dataLogger.Status.Conditions[0].Message = "unable to create the deployment"
+			},
+			err: "dataLogger my-namespace1/datalogger-sample-42 is not ready after 20ms: ReconcileFailed: unable to create the deployment",
+		},
+		{
+			name: "deleting",
+			mutate: func(dataLogger *appv1.DataLogger) {
+				dataLogger.ObjectMeta.Finalizers = []string{"finalizer.stackit.cloud/datalogger"}
+				dataLogger.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			},
+			err: "dataLogger my-namespace1/datalogger-sample-42 is being deleted",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			dataLogger, err := newClient(newDataLogger(test.mutate)).WaitReady(ctx, key, 20*time.Millisecond)
+
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.EqualValues(t, key.Name, dataLogger.Name)
+		})
+	}
+}
+
+func TestClientWaitReadyObservesProgress(t *testing.T) {
+	ctx := context.Background()
+
+	sdk := newClient(newDataLogger(func(dataLogger *appv1.DataLogger) {
+		dataLogger.Status.Conditions = nil
+	}))
+
+	// The operator reports the dataLogger as ready while it is waited for
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+
+		dataLogger := &appv1.DataLogger{}
+		if err := sdk.apiClient.Get(ctx, key, dataLogger); err != nil {
+			return
+		}
+
+		dataLogger.Status = newDataLogger(nil).Status
+		_ = sdk.apiClient.Update(ctx, dataLogger)
+	}()
+
+	dataLogger, err := sdk.WaitReady(ctx, key, time.Second)
+	require.Nil(t, err)
+	require.EqualValues(t, "datalogger-42.my-namespace1.svc:8080", dataLogger.Status.Endpoint)
+}
+
+func TestClientUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	sdk := newClient(newDataLogger(nil))
+
+	updated, err := sdk.Update(ctx, key, func(dataLogger *appv1.DataLogger) {
+		dataLogger.Spec.Replicas = 3
+	})
+	require.Nil(t, err)
+	require.EqualValues(t, 3, updated.Spec.Replicas)
+
+	sdk = newClient(newDataLogger(func(dataLogger *appv1.DataLogger) {
+		dataLogger.ObjectMeta.Finalizers = []string{"finalizer.stackit.cloud/datalogger"}
+		dataLogger.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	}))
+
+	_, err = sdk.Update(ctx, key, func(dataLogger *appv1.DataLogger) {
+		dataLogger.Spec.Replicas = 3
+	})
+	require.EqualError(t, err, "dataLogger my-namespace1/datalogger-sample-42 is being deleted")
+}
+
+func TestClientDelete(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		objs   []client.Object
+		mutate func(dataLogger *appv1.DataLogger)
+		err    string
+	}{
+		{
+			name: "without-finalizer",
+		},
+		{
+			name: "not-found",
+			objs: []client.Object{},
+		},
+		{
+			name: "finalizing",
+			mutate: func(dataLogger *appv1.DataLogger) {
+				dataLogger.ObjectMeta.Finalizers = []string{"finalizer.stackit.cloud/datalogger"}
+				dataLogger.Status.Conditions = append(dataLogger.Status.Conditions, metav1.Condition{
+					Type: appv1.ConditionFinalizing, Status: metav1.ConditionTrue,
+					Reason: "WaitingForDeletion", Message: "waiting for Deployment datalogger-42 to be deleted",
+				})
+			},
+			err: "dataLogger my-namespace1/datalogger-sample-42 is not deleted after 20ms: " +
+				"waiting for Deployment datalogger-42 to be deleted",
+		},
+		{
+			name: "stuck",
+			mutate: func(dataLogger *appv1.DataLogger) {
+				dataLogger.ObjectMeta.Finalizers = []string{"finalizer.stackit.cloud/datalogger"}
+				dataLogger.Status.Conditions = append(dataLogger.Status.Conditions, metav1.Condition{
+					Type: appv1.ConditionFinalizeStuck, Status: metav1.ConditionTrue,
+					Reason: "TimedOut", Message: "waiting for Deployment datalogger-42 to be deleted for more than 5m0s",
+				})
+			},
+			err: "dataLogger my-namespace1/datalogger-sample-42 is stuck finalizing: " +
+				"waiting for Deployment datalogger-42 to be deleted for more than 5m0s",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			objs := test.objs
+			if objs == nil {
+				objs = []client.Object{newDataLogger(test.mutate)}
+			}
+
+			err := newClient(objs...).Delete(ctx, key, 20*time.Millisecond)
+
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestClientEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	endpoint, err := newClient(newDataLogger(nil)).Endpoint(ctx, key)
+	require.Nil(t, err)
+	require.EqualValues(t, "datalogger-42.my-namespace1.svc:8080", endpoint)
+
+	_, err = newClient(newDataLogger(func(dataLogger *appv1.DataLogger) {
+		dataLogger.Status.Endpoint = ""
+	})).Endpoint(ctx, key)
+	require.EqualError(t, err, "dataLogger my-namespace1/datalogger-sample-42 has no endpoint yet")
+}
@@ -3,117 +3,83 @@ package service
 import (
 	"context"
 
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	appv1 "stackit.cloud/datalogger/api/v1"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/requeue"
+	"stackit.cloud/datalogger/pkg/utils/apply"
 )
 
 type Service struct {
 	reference pkg.ServiceReferenceController
+	drift     pkg.DriftOperator
+	recorder  pkg.EventRecorderOperator
 }
 
-func NewService(reference pkg.ServiceReferenceController) *Service {
-	return &Service{reference: reference}
+func NewService(
+	reference pkg.ServiceReferenceController,
+	drift pkg.DriftOperator,
+	recorder pkg.EventRecorderOperator,
+) *Service {
+	return &Service{reference: reference, drift: drift, recorder: recorder}
 }
 
-func (s Service) Reconcile(ctx context.Context, req ctrl.Request, r pkg.APIClientOperator) error {
+func (s Service) Reconcile(ctx context.Context, scope *pkg.Scope, r pkg.APIClientOperator) error {
 	logger := log.FromContext(ctx)
 
-	dataLogger := &appv1.DataLogger{}
+	dataLogger := scope.DataLogger
 
-	err := r.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: req.Name}, dataLogger)
-	// Ignore not found error, reconcile will be called again when the resource is created.
-	if errors.IsNotFound(err) {
-		return nil
-	}
+	// Fetch the current Service to skip the write if nothing changed since the last apply and it did not drift
+	var live client.Object
 
-	if err != nil {
+	current := &corev1.Service{}
+
+	err := r.Get(ctx, client.ObjectKey{Name: dataLogger.Spec.CustomName, Namespace: dataLogger.Namespace}, current)
+	if client.IgnoreNotFound(err) != nil {
 		return err
 	}
 
-	// Fetch the corresponding Deployment
-	deployment := &appsv1.Deployment{}
-
-	err = r.Get(ctx, client.ObjectKey{Name: dataLogger.Spec.CustomName, Namespace: dataLogger.Namespace}, deployment)
-	if err != nil {
-		return err
+	if err == nil {
+		live = current
 	}
 
-	// Fetch or create the corresponding Service
-	service := &corev1.Service{}
+	service := s.NewServiceForDataLogger(dataLogger)
 
-	err = r.Get(ctx, client.ObjectKey{
-		Name:      dataLogger.Spec.CustomName,
-		Namespace: dataLogger.ObjectMeta.Namespace,
-	}, service)
+	// Set owner reference to the dataLogger instance
+	err = s.reference.SetControllerReference(dataLogger, service, r.Scheme())
 	if err != nil {
-		// Service not found, create a new one
-		service = s.NewServiceForDataLogger(dataLogger)
-
-		err = r.Create(ctx, service)
-		if err != nil {
-			return err
-		}
+		// The object is controlled by another owner, retrying does not help
+		return requeue.Terminal(err)
+	}
 
-		logger.Info("Service was created for dataLogger", service.Name, service.Namespace)
+	written, err := apply.PatchIfChanged(ctx, r, s.drift, dataLogger, service, live)
+	if err != nil {
+		return err
+	}
 
+	if !written {
+		scope.SetChild("Service", current)
 		return nil
 	}
 
-	// Reconcile the Service's selector to match the Deployment's Pods
-	if !s.reference.IsControlledBy(service, deployment) {
-		service = s.UpdateService(service, deployment, dataLogger)
+	// The apply filled service with the response of the API server
+	scope.SetChild("Service", service)
 
-		err = r.Update(ctx, service)
-		if err != nil {
-			return err
-		}
+	logger.Info("Service was applied for dataLogger", "name", service.Name, "namespace", service.Namespace)
 
-		logger.Info("Service was updated to match Deployment", service.Name, deployment.Name)
+	if live == nil {
+		s.recorder.Eventf(dataLogger, corev1.EventTypeNormal, pkg.EventReasonCreated, "Created Service %s", service.Name)
+	} else {
+		s.recorder.Eventf(dataLogger, corev1.EventTypeNormal, pkg.EventReasonUpdated, "Updated Service %s", service.Name)
 	}
 
 	return nil
 }
 
-func (Service) UpdateService(svc *corev1.Service, dep *appsv1.Deployment, dLog *appv1.DataLogger) *corev1.Service {
-	// Ensure that the Deployment's Pods have a specific label for the Service to select
-	labels := map[string]string{"app": dLog.Spec.CustomName}
-
-	// The Service is not controlled by the Deployment, update it
-	svc.ObjectMeta.Labels = labels
-	svc.ObjectMeta.OwnerReferences = []metav1.OwnerReference{
-		*metav1.NewControllerRef(dep, schema.GroupVersionKind{
-			Group:   "apps",
-			Version: "v1",
-			Kind:    "Deployment",
-		}),
-	}
-
-	svc.Spec = corev1.ServiceSpec{
-		Selector: map[string]string{
-			"app": dLog.Spec.CustomName,
-		},
-		Ports: []corev1.ServicePort{
-			{
-				Port:       dLog.Spec.Port,
-				TargetPort: intstr.FromInt32(dLog.Spec.TargetPort),
-				NodePort:   dLog.Spec.NodePort,
-			},
-		},
-		Type: corev1.ServiceTypeNodePort, // Set the Service Type to NodePort
-	}
-
-	return svc
-}
-
 // NewServiceForDataLogger creates a new Service for the given dataLogger CR
 func (Service) NewServiceForDataLogger(dataLogger *appv1.DataLogger) *corev1.Service {
 	labels := map[string]string{
@@ -121,13 +87,14 @@ func (Service) NewServiceForDataLogger(dataLogger *appv1.DataLogger) *corev1.Ser
 	}
 
 	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Service",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      dataLogger.Spec.CustomName,
 			Namespace: dataLogger.Namespace,
 			Labels:    labels,
-			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(dataLogger, appsv1.SchemeGroupVersion.WithKind("dataLogger")),
-			},
 		},
 		Spec: corev1.ServiceSpec{
 			Selector: labels,
@@ -138,7 +105,17 @@ func (Service) NewServiceForDataLogger(dataLogger *appv1.DataLogger) *corev1.Ser
 					NodePort:   dataLogger.Spec.NodePort,
 				},
 			},
-			Type: corev1.ServiceTypeNodePort, // Set the Service Type to NodePort
+			Type: ServiceType(dataLogger),
 		},
 	}
 }
+
+// ServiceType returns the type of the service for the given dataLogger. DataLoggers that were
+// not defaulted by the webhook keep the NodePort type the operator used before.
+func ServiceType(dataLogger *appv1.DataLogger) corev1.ServiceType {
+	if dataLogger.Spec.ServiceType != "" {
+		return dataLogger.Spec.ServiceType
+	}
+
+	return corev1.ServiceTypeNodePort
+}
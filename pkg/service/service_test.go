@@ -3,24 +3,27 @@ package service
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	appv1 "stackit.cloud/datalogger/api/v1"
+	appv2 "stackit.cloud/datalogger/api/v2"
 	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/utils/apply"
+	"stackit.cloud/datalogger/pkg/utils/hash"
 )
 
 func TestServiceReconcileWithNoErrors(t *testing.T) {
@@ -31,8 +34,7 @@ func TestServiceReconcileWithNoErrors(t *testing.T) {
 	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
 
 	mockedReference := pkg.NewMockServiceReferenceController(mockCtrl)
-
-	reconciler := NewService(mockedReference)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
 
 	tests := []struct {
 		name        string
@@ -65,32 +67,27 @@ func TestServiceReconcileWithNoErrors(t *testing.T) {
 		t.Run(fmt.Sprintf("rental-%s", test.name), func(t *testing.T) {
 			t.Parallel()
 
-			reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-			req := reconcile.Request{NamespacedName: reqType}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewService(mockedReference, mockedDrift, recorder)
 
 			dataLogger := &appv1.DataLogger{}
-			apiClient.EXPECT().Get(
-				ctx,
-				client.ObjectKey{Name: test.name, Namespace: test.namespace},
-				dataLogger, gomock.Any(),
-			).Times(1).Do(
-				func(ctx context.Context, c client.ObjectKey, dataLogger *appv1.DataLogger, opts ...interface{}) error {
-					reflect.ValueOf(dataLogger).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").SetString(test.namespace)
-					reflect.ValueOf(dataLogger).Elem().FieldByName("Spec").FieldByName("CustomName").SetString(test.name)
-					return nil
-				},
-			).Return(nil)
-
-			deployment := &appsv1.Deployment{}
-			apiClient.EXPECT().Get(ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, deployment).Times(1).Return(nil)
-
-			service := &corev1.Service{}
-			apiClient.EXPECT().Get(ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, service).Times(1).Return(nil)
-
-			mockedReference.EXPECT().IsControlledBy(service, deployment).Times(1).Return(true)
-
-			err := reconciler.Reconcile(ctx, req, apiClient)
+			dataLogger.ObjectMeta.Namespace = test.namespace
+			dataLogger.Spec.CustomName = test.name
+
+			apiClient.EXPECT().Get(ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, &corev1.Service{}).Times(1).Return(nil)
+
+			service := reconciler.NewServiceForDataLogger(dataLogger)
+
+			apiClient.EXPECT().Scheme().Times(1).Return(nil)
+			mockedReference.EXPECT().SetControllerReference(dataLogger, service, nil).Times(1).Return(test.errorValue1)
+
+			apiClient.EXPECT().Patch(
+				ctx, withHash(t, service), client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+			).Times(1).Return(test.errorValue2)
+
+			err := reconciler.Reconcile(ctx, pkg.NewScope(dataLogger), apiClient)
 			require.Nil(t, err)
+			require.EqualValues(t, "Normal Updated Updated Service "+test.name, <-recorder.Events)
 		})
 	}
 }
@@ -103,6 +100,10 @@ func TestServiceReconcileWithErrors(t *testing.T) {
 	apiClient := pkg.NewMockAPIClientOperator(mockCtrl)
 
 	mockedReference := pkg.NewMockServiceReferenceController(mockCtrl)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
+
+	scheme := runtime.NewScheme()
+	require.Nil(t, appv1.AddToScheme(scheme))
 
 	tests := []struct {
 		name        string
@@ -114,27 +115,18 @@ func TestServiceReconcileWithErrors(t *testing.T) {
 		notFound    *errors2.StatusError
 	}{
 		{
-			name:        "DataLoggerController-1",
-			namespace:   "my-namespace1",
-			errorValue1: errors.New("get error 1"),
-			errorValue2: nil,
-			times:       1,
-			want:        ctrl.Result{Requeue: false},
-		},
-		{
-			name:        "DataLoggerController-1.1",
-			namespace:   "my-namespace1",
+			name:        "DataLoggerController-2",
+			namespace:   "my-namespace2",
 			errorValue1: nil,
-			errorValue2: nil,
+			errorValue2: errors.New("get error 2"),
 			times:       1,
 			want:        ctrl.Result{Requeue: false},
-			notFound:    errors2.NewNotFound(schema.GroupResource{Group: "", Resource: "resources"}, "resource-name"),
 		},
 		{
-			name:        "DataLoggerController-2",
+			name:        "DataLoggerController-2.1",
 			namespace:   "my-namespace2",
-			errorValue1: nil,
-			errorValue2: errors.New("get error 2"),
+			errorValue1: errors.New("owner error"),
+			errorValue2: errors.New("owner error"),
 			times:       1,
 			want:        ctrl.Result{Requeue: false},
 		},
@@ -154,151 +146,59 @@ func TestServiceReconcileWithErrors(t *testing.T) {
 		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
 			t.Parallel()
 
-			if test.errorValue1 != nil && test.errorValue2 == nil {
-				reconciler := NewService(mockedReference)
-
-				reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-				req := reconcile.Request{NamespacedName: reqType}
+			if test.errorValue2 != nil {
+				recorder := record.NewFakeRecorder(10)
+				reconciler := NewService(mockedReference, mockedDrift, recorder)
 
 				dataLogger := &appv1.DataLogger{}
+				dataLogger.ObjectMeta.Namespace = test.namespace
+				dataLogger.ObjectMeta.Name = test.name
+				dataLogger.Spec.CustomName = test.name
 
 				apiClient.EXPECT().Get(
-					ctx,
-					client.ObjectKey{Name: test.name, Namespace: test.namespace},
-					dataLogger, gomock.Any(),
-				).Times(1).Return(test.errorValue1)
+					ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, &corev1.Service{},
+				).Times(1).Return(errors2.NewNotFound(schema.GroupResource{Resource: "services"}, test.name))
 
-				err := reconciler.Reconcile(ctx, req, apiClient)
-				require.EqualValues(t, err.Error(), "get error 1")
-			} else if test.errorValue1 == nil && test.errorValue2 == nil && test.notFound != nil {
-				reconciler := NewService(mockedReference)
+				service := reconciler.NewServiceForDataLogger(dataLogger)
 
-				reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-				req := reconcile.Request{NamespacedName: reqType}
+				apiClient.EXPECT().Scheme().Times(1).Return(nil)
+				mockedReference.EXPECT().SetControllerReference(dataLogger, service, nil).Times(1).Return(test.errorValue1)
 
-				dataLogger := &appv1.DataLogger{}
-
-				apiClient.EXPECT().Get(
-					ctx,
-					client.ObjectKey{Name: test.name, Namespace: test.namespace},
-					dataLogger, gomock.Any(),
-				).Times(1).Return(test.notFound)
-
-				err := reconciler.Reconcile(ctx, req, apiClient)
-				require.Nil(t, err)
-
-				return
-			} else if test.errorValue1 == nil && test.errorValue2 != nil && test.notFound == nil {
-				reconciler := NewService(mockedReference)
-
-				reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-				req := reconcile.Request{NamespacedName: reqType}
-
-				dataLogger := &appv1.DataLogger{}
-				apiClient.EXPECT().Get(
-					ctx,
-					client.ObjectKey{Name: test.name, Namespace: test.namespace},
-					dataLogger, gomock.Any(),
-				).Times(1).Do(
-					func(ctx context.Context, c client.ObjectKey, deps *appv1.DataLogger, opts ...interface{}) error {
-						reflect.ValueOf(deps).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").SetString(test.namespace)
-						reflect.ValueOf(deps).Elem().FieldByName("Spec").FieldByName("CustomName").SetString(test.name)
-						return nil
-					},
-				).Return(nil)
-
-				deployment := &appsv1.Deployment{}
-
-				apiClient.EXPECT().Get(
-					ctx,
-					client.ObjectKey{Name: test.name, Namespace: test.namespace},
-					deployment, gomock.Any(),
-				).Times(1).Return(test.errorValue1)
-
-				service := &corev1.Service{}
-
-				apiClient.EXPECT().Get(
-					ctx,
-					client.ObjectKey{Name: test.name, Namespace: test.namespace},
-					service, gomock.Any(),
-				).Times(1).Return(test.errorValue2)
-
-				service = reconciler.NewServiceForDataLogger(dataLogger)
-
-				service.ObjectMeta.Name = test.name
-				service.ObjectMeta.Namespace = test.namespace
-				labels := map[string]string{
-					"app": test.name,
+				if test.errorValue1 == nil {
+					apiClient.EXPECT().Patch(
+						ctx, withHash(t, service), client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+					).Times(1).Return(test.errorValue2)
 				}
 
-				service.ObjectMeta.Labels = labels
-				service.Spec.Selector = labels
-
-				apiClient.EXPECT().Create(ctx, gomock.Eq(service)).Times(1).Return(test.errorValue1)
-
-				err := reconciler.Reconcile(ctx, req, apiClient)
-				require.Nil(t, err)
+				err := reconciler.Reconcile(ctx, pkg.NewScope(dataLogger), apiClient)
+				require.EqualValues(t, err.Error(), test.errorValue2.(error).Error())
+				require.Empty(t, recorder.Events)
 
 				return
 			} else {
-				reconciler := NewService(mockedReference)
-
-				reqType := types.NamespacedName{Namespace: test.namespace, Name: test.name}
-				req := reconcile.Request{NamespacedName: reqType}
+				recorder := record.NewFakeRecorder(10)
+				reconciler := NewService(mockedReference, mockedDrift, recorder)
 
 				dataLogger := &appv1.DataLogger{}
-				apiClient.EXPECT().Get(
-					ctx,
-					client.ObjectKey{Name: test.name, Namespace: test.namespace},
-					dataLogger, gomock.Any(),
-				).Times(1).Do(
-					func(ctx context.Context, c client.ObjectKey, deps *appv1.DataLogger, opts ...interface{}) error {
-						reflect.ValueOf(deps).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").SetString(test.namespace)
-						reflect.ValueOf(deps).Elem().FieldByName("Spec").FieldByName("CustomName").SetString(test.name)
-
-						return nil
-					},
-				).Return(nil)
-
-				deployment := &appsv1.Deployment{}
+				dataLogger.ObjectMeta.Namespace = test.namespace
+				dataLogger.ObjectMeta.Name = test.name
+				dataLogger.Spec.CustomName = test.name
 
-				labels := metav1.LabelSelector{
-					MatchLabels:      map[string]string{"app": test.name},
-					MatchExpressions: nil,
+				svc := &corev1.Service{
+					TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
 				}
 
-				apiClient.EXPECT().Get(
-					ctx,
-					client.ObjectKey{Name: test.name, Namespace: test.namespace},
-					deployment, gomock.Any(),
-				).Times(1).Do(func(ctx context.Context, c client.ObjectKey, deps *appsv1.Deployment, opts ...interface{}) error {
-					deps.Spec.Selector = &labels
-
-					return nil
-				}).Return(nil)
-
-				service := &corev1.Service{}
-
-				apiClient.EXPECT().Get(
-					ctx,
-					client.ObjectKey{Name: test.name, Namespace: test.namespace},
-					service, gomock.Any(),
-				).Times(1).Return(nil)
-
-				dep := &appsv1.Deployment{}
-				svc := &corev1.Service{}
-
-				// labels := map[string]string{"app": test.name}
-				metaLabels := map[string]string{"app": test.name}
-
-				// The Service is not controlled by the Deployment, update it
-				svc.ObjectMeta.Labels = metaLabels
+				svc.ObjectMeta.Name = test.name
+				svc.ObjectMeta.Namespace = test.namespace
+				svc.ObjectMeta.Labels = map[string]string{"app": test.name}
 				svc.ObjectMeta.OwnerReferences = []metav1.OwnerReference{
-					*metav1.NewControllerRef(dep, schema.GroupVersionKind{
-						Group:   "apps",
-						Version: "v1",
-						Kind:    "Deployment",
-					}),
+					{
+						APIVersion:         appv1.GroupVersion.String(),
+						Kind:               "DataLogger",
+						Name:               test.name,
+						Controller:         ptr.To(true),
+						BlockOwnerDeletion: ptr.To(true),
+					},
 				}
 
 				svc.Spec = corev1.ServiceSpec{
@@ -315,15 +215,101 @@ func TestServiceReconcileWithErrors(t *testing.T) {
 					Type: corev1.ServiceTypeNodePort, // Set the Service Type to NodePort
 				}
 
-				dep1 := &appsv1.Deployment{}
-				dep1.Spec.Selector = &labels
-				mockedReference.EXPECT().IsControlledBy(service, dep1).Times(1).Return(false)
-				apiClient.EXPECT().Update(ctx, svc).Times(1).Return(nil)
+				apiClient.EXPECT().Get(
+					ctx, client.ObjectKey{Name: test.name, Namespace: test.namespace}, &corev1.Service{},
+				).Times(1).Return(nil)
+
+				// Let the reference controller set a real owner reference, pointing at the dataLogger
+				apiClient.EXPECT().Scheme().Times(1).Return(scheme)
+				mockedReference.EXPECT().SetControllerReference(dataLogger, gomock.Any(), scheme).Times(1).DoAndReturn(
+					func(owner, controlled metav1.Object, scheme *runtime.Scheme) error {
+						return controllerutil.SetControllerReference(owner, controlled, scheme)
+					},
+				)
+
+				apiClient.EXPECT().Patch(
+					ctx, withHash(t, svc), client.Apply, client.FieldOwner(apply.FieldManager), client.ForceOwnership,
+				).Times(1).Return(nil)
+
+				scope := pkg.NewScope(dataLogger)
 
-				err := reconciler.Reconcile(ctx, req, apiClient)
+				err := reconciler.Reconcile(ctx, scope, apiClient)
 
 				require.Nil(t, err)
+				require.EqualValues(t, "Normal Updated Updated Service "+test.name, <-recorder.Events)
+
+				child, ok := scope.Child("Service")
+				require.True(t, ok)
+				require.EqualValues(t, svc.Spec, child.(*corev1.Service).Spec)
 			}
 		})
 	}
 }
+
+func TestNewServiceForDataLoggerWithDefaults(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	mockedReference := pkg.NewMockServiceReferenceController(mockCtrl)
+	mockedDrift := pkg.NewMockDriftOperator(mockCtrl)
+
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewService(mockedReference, mockedDrift, recorder)
+
+	tests := []struct {
+		name        string
+		spec        appv1.DataLoggerSpec
+		port        int32
+		targetPort  int32
+		nodePort    int32
+		serviceType corev1.ServiceType
+	}{
+		{
+			name:        "DataLoggerController-1",
+			spec:        appv1.DataLoggerSpec{CustomName: "datalogger-1"},
+			port:        appv2.DefaultPort,
+			targetPort:  appv2.DefaultPort,
+			serviceType: corev1.ServiceTypeClusterIP,
+		},
+		{
+			name:        "DataLoggerController-2",
+			spec:        appv1.DataLoggerSpec{CustomName: "datalogger-2", Port: 8080, TargetPort: 80, NodePort: 32101},
+			port:        8080,
+			targetPort:  80,
+			nodePort:    32101,
+			serviceType: corev1.ServiceTypeNodePort,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("datalogger-%s", test.name), func(t *testing.T) {
+			t.Parallel()
+
+			dataLogger := &appv1.DataLogger{Spec: test.spec}
+
+			defaulter := &appv1.DataLoggerDefaulter{}
+			defaulter.SetDefaults(&dataLogger.Spec)
+
+			service := reconciler.NewServiceForDataLogger(dataLogger)
+
+			require.EqualValues(t, test.serviceType, service.Spec.Type)
+			require.EqualValues(t, test.port, service.Spec.Ports[0].Port)
+			require.EqualValues(t, intstr.FromInt32(test.targetPort), service.Spec.Ports[0].TargetPort)
+			require.EqualValues(t, test.nodePort, service.Spec.Ports[0].NodePort)
+		})
+	}
+}
+
+// withHash returns a copy of the service carrying the hash annotation of its desired state
+func withHash(t *testing.T, service *corev1.Service) *corev1.Service {
+	t.Helper()
+
+	hashed := service.DeepCopy()
+
+	annotations, err := hash.ComputeToAnnotation(hashed)
+	require.Nil(t, err)
+
+	hashed.SetAnnotations(annotations)
+
+	return hashed
+}
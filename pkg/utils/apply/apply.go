@@ -0,0 +1,105 @@
+// Package apply contains the server-side apply utility's
+package apply
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"stackit.cloud/datalogger/pkg"
+	"stackit.cloud/datalogger/pkg/metrics"
+	"stackit.cloud/datalogger/pkg/utils/hash"
+)
+
+// FieldManager is the field manager the operator applies its objects with
+const FieldManager = "datalogger-operator"
+
+// Patch applies obj with the field manager of the operator. Conflicts are forced,
+// so obj must only contain the fields the operator owns.
+func Patch(ctx context.Context, r pkg.APIClientOperator, obj client.Object) error {
+	return r.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
+
+// PatchIfChanged applies obj unless current, the object in the cluster, already carries the
+// hash of obj and did not drift from it. current is nil if the object does not exist yet.
+// It reports whether obj was written.
+func PatchIfChanged(
+	ctx context.Context,
+	r pkg.APIClientOperator,
+	drift pkg.DriftOperator,
+	owner, obj, current client.Object,
+) (bool, error) {
+	annotations, err := hash.ComputeToAnnotation(obj)
+	if err != nil {
+		return false, err
+	}
+
+	merged := obj.GetAnnotations()
+	for key, value := range annotations {
+		merged[key] = value
+	}
+	obj.SetAnnotations(merged)
+
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+
+	if current != nil && hash.Equal(ctx, obj, current) {
+		// the desired state did not change, so any difference was made by someone else
+		drifted, err := drift.Detect(ctx, owner, obj, current)
+		if err != nil {
+			return false, err
+		}
+
+		if !drifted {
+			metrics.Writes.WithLabelValues(kind, metrics.ResultSkipped).Inc()
+			return false, nil
+		}
+	}
+
+	err = Patch(ctx, r, obj)
+	if err != nil {
+		return false, err
+	}
+
+	metrics.Writes.WithLabelValues(kind, metrics.ResultApplied).Inc()
+
+	return true, nil
+}
+
+// ManagedThrough returns true if another field manager owns the field at path by writing
+// the given subresource, e.g. an autoscaler writing "spec", "replicas" through "scale".
+func ManagedThrough(obj client.Object, subresource string, path ...string) bool {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == FieldManager || entry.Subresource != subresource || entry.FieldsV1 == nil {
+			continue
+		}
+
+		if hasField(entry.FieldsV1, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasField checks if the managed fields set contains the field at path
+func hasField(fields *metav1.FieldsV1, path []string) bool {
+	set := map[string]any{}
+	if err := json.Unmarshal(fields.Raw, &set); err != nil {
+		return false
+	}
+
+	for _, name := range path {
+		value, ok := set["f:"+name]
+		if !ok {
+			return false
+		}
+
+		set, ok = value.(map[string]any)
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}